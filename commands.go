@@ -1,9 +1,9 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -34,6 +34,12 @@ var (
 			Description: "Feed Delay (x Minutes)",
 			Required:    false,
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "schedule",
+			Description: "Schedule instead of Delay (cron, \"every 15m\", \"weekdays 9-17\")",
+			Required:    false,
+		},
 		{
 			Type:        discordgo.ApplicationCommandOptionMentionable,
 			Name:        "tag",
@@ -88,6 +94,130 @@ var (
 		},
 	}
 
+	feedTypeChoices = []*discordgo.ApplicationCommandOptionChoice{
+		{
+			Name:  "Flickr Groups",
+			Value: feedFlickrGroup,
+		},
+		{
+			Name:  "Flickr Users",
+			Value: feedFlickrUser,
+		},
+		{
+			Name:  "Instagram Accounts",
+			Value: feedInstagramAccount,
+		},
+		{
+			Name:  "NASA APOD",
+			Value: feedAPOD,
+		},
+		{
+			Name:  "Plex Servers",
+			Value: feedPlexServer,
+		},
+		{
+			Name:  "RSS Feeds",
+			Value: feedRSS,
+		},
+		{
+			Name:  "Spotify Artists",
+			Value: feedSpotifyArtist,
+		},
+		{
+			Name:  "Spotify Playlists",
+			Value: feedSpotifyPlaylist,
+		},
+		{
+			Name:  "Spotify Podcasts",
+			Value: feedSpotifyPodcast,
+		},
+		{
+			Name:  "Twitch Live",
+			Value: feedTwitchLive,
+		},
+		{
+			Name:  "Twitter Accounts",
+			Value: feedTwitterAccount,
+		},
+		{
+			Name:  "Twitter Trends",
+			Value: feedTwitterTrends,
+		},
+	}
+
+	feedControlOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "name",
+			Description: "Feed Name",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "type",
+			Description: "Feed Type, if the name is used by more than one",
+			Required:    false,
+			Choices:     feedTypeChoices,
+		},
+	}
+
+	apodOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "prefer-hd",
+			Description: "Post the HD Image When Available (Default: true)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "explanation-length",
+			Description: "Max Explanation Length (Default: 1000, 0 to Leave Out)",
+			Required:    false,
+		},
+	}
+
+	plexOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-url",
+			Description: "Change Plex Server URL",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "libraries",
+			Description: "Only These Libraries, Names or IDs (sep by \",\", empty for all)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "types",
+			Description: "Only These Types: movie, show, episode, music (sep by \",\", empty for all)",
+			Required:    false,
+		},
+	}
+
+	twitchLiveOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-channel",
+			Description: "Change Twitch Channel",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "role",
+			Description: "Role to Mention in This Channel",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "edit-on-end",
+			Description: "Edit Post With Duration & Peak Viewers When the Stream Ends (Default: true)",
+			Required:    false,
+		},
+	}
+
 	twitterOpts = []*discordgo.ApplicationCommandOption{
 		{
 			Type:        discordgo.ApplicationCommandOptionString,
@@ -141,6 +271,138 @@ var (
 		},
 	}
 
+	twitterTrendsOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-location",
+			Description: "Change Location, WOEID or Place Name",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "role",
+			Description: "Role to Mention for Keyword Alerts in This Channel",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "top",
+			Description: "Size of the Ranked List (Default: 10, Max: 50)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "keywords",
+			Description: "Watched Terms to Alert On (sep by \",\", empty to clear)",
+			Required:    false,
+		},
+	}
+
+	flickrOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-id",
+			Description: "Change Flickr ID (NSID, Alias or Link)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "photo-tags",
+			Description: "Only Post Photos With Any of These Tags (Comma Separated)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "exclude-tags",
+			Description: "Skip Photos With Any of These Tags (Comma Separated)",
+			Required:    false,
+		},
+	}
+
+	instagramOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-handle",
+			Description: "Change Instagram Handle (@)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-posts",
+			Description: "Include Posts (Default: true)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-reels",
+			Description: "Include Reels (Default: true)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-stories",
+			Description: "Include Stories (Default: false)",
+			Required:    false,
+		},
+	}
+
+	spotifyArtistOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-artist",
+			Description: "Change Spotify Artist (ID or Link)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-albums",
+			Description: "Include Albums (Default: true)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-singles",
+			Description: "Include Singles (Default: true)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-appearances",
+			Description: "Include Releases the Artist Appears On (Default: true)",
+			Required:    false,
+		},
+	}
+
+	spotifyPlaylistOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-playlist",
+			Description: "Change Spotify Playlist (ID or Link)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "include-removals",
+			Description: "Post Removed Tracks (Default: false)",
+			Required:    false,
+		},
+	}
+
+	spotifyPodcastOpts = []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "change-show",
+			Description: "Change Spotify Show (ID or Link)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "description-length",
+			Description: "Characters of Episode Description (Default: 500)",
+			Required:    false,
+		},
+	}
+
 	// https://github.com/bwmarrin/discordgo/blob/master/examples/slash_commands/main.go
 	commands = []*discordgo.ApplicationCommand{
 
@@ -170,317 +432,2252 @@ var (
 					Name:        "filter",
 					Description: "Filter by group",
 					Required:    false,
-					Choices: []*discordgo.ApplicationCommandOptionChoice{
-						{
-							Name:  "ALL (Default)",
-							Value: -1,
-						},
-						{
-							Name:  "Instagram Accounts",
-							Value: feedInstagramAccount,
-						},
-						{
-							Name:  "RSS Feeds",
-							Value: feedRSS,
-						},
-						{
-							Name:  "Twitter Accounts",
-							Value: feedTwitterAccount,
-						},
-					},
+					Choices: append([]*discordgo.ApplicationCommandOptionChoice{{
+						Name:  "ALL (Default)",
+						Value: -1,
+					}}, feedTypeChoices...),
+				},
+			},
+		},
+		{
+			Name:        "feed",
+			Description: "Control an existing feed",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "pause",
+					Description: "Stop running a feed, keeping its config",
+					Options:     feedControlOpts,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "resume",
+					Description: "Resume a paused feed",
+					Options:     feedControlOpts,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "run",
+					Description: "Run a feed now, out of schedule",
+					Options:     feedControlOpts,
 				},
 			},
 		},
 		//#endregion
 
-		//#region RSS Feeds
+		//#region Flickr Groups
 		{
-			Name:        "rss-new",
+			Name:        "flickr-group-new",
 			Description: "Add a new feed",
 			Options: append(append([]*discordgo.ApplicationCommandOption{{
 				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "url",
-				Description: "RSS Feed URL",
+				Name:        "group",
+				Description: "Flickr Group (NSID, Alias or Link)",
 				Required:    true,
-			}}, genericCommandOpts...), []*discordgo.ApplicationCommandOption{{
+			}}, genericCommandOpts...), flickrOpts[1:]...),
+		},
+		{
+			Name:        "flickr-group-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "flickr-group-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, flickrOpts...),
+		},
+		{
+			Name:        "flickr-group-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "flickr-group-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Flickr Users
+		{
+			Name:        "flickr-user-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
 				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "twitter",
-				Description: "Twitter for Username & Avatar",
-				Required:    false,
-			}}...),
+				Name:        "user",
+				Description: "Flickr User (NSID, Alias or Link)",
+				Required:    true,
+			}}, genericCommandOpts...), flickrOpts[1:]...),
 		},
 		{
-			Name:        "rss-add",
+			Name:        "flickr-user-add",
 			Description: "Add this channel to an existing feed",
 			Options:     nameCommandOpt,
 		},
 		{
-			Name:        "rss-modify",
+			Name:        "flickr-user-modify",
 			Description: "Modify an existing feed",
-			Options: append(genericCommandOpts, []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "change-url",
-					Description: "Modify RSS Feed URL",
-					Required:    false,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "blacklist-url",
-					Description: "Add Blacklist Line (sep by \"|\") for URL contents",
-					Required:    false,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "twitter",
-					Description: "Twitter for Username & Avatar",
-					Required:    false,
-				},
-			}...),
+			Options:     append(genericCommandOpts, flickrOpts...),
 		},
 		{
-			Name:        "rss-delete",
+			Name:        "flickr-user-delete",
 			Description: "Delete an existing feed",
 			Options:     nameCommandOpt,
 		},
 		{
-			Name:        "rss-show",
+			Name:        "flickr-user-show",
 			Description: "Display info for an existing feed",
 			Options:     nameCommandOpt,
 		},
 		//#endregion
 
-		//#region Twitter Accounts
+		//#region Instagram Accounts
 		{
-			Name:        "twitter-new",
+			Name:        "instagram-new",
 			Description: "Add a new feed",
 			Options: append(append([]*discordgo.ApplicationCommandOption{{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "handle",
-				Description: "Twitter Handle (@)",
+				Description: "Instagram Handle (@)",
 				Required:    true,
-			}}, genericCommandOpts...), twitterOpts...),
+			}}, genericCommandOpts...), instagramOpts[1:]...),
 		},
 		{
-			Name:        "twitter-add",
+			Name:        "instagram-add",
 			Description: "Add this channel to an existing feed",
 			Options:     nameCommandOpt,
 		},
 		{
-			Name:        "twitter-modify",
+			Name:        "instagram-modify",
 			Description: "Modify an existing feed",
-			Options:     append(genericCommandOpts, twitterOpts...),
+			Options:     append(genericCommandOpts, instagramOpts...),
 		},
 		{
-			Name:        "twitter-delete",
+			Name:        "instagram-delete",
 			Description: "Delete an existing feed",
 			Options:     nameCommandOpt,
 		},
 		{
-			Name:        "twitter-show",
+			Name:        "instagram-show",
 			Description: "Display info for an existing feed",
 			Options:     nameCommandOpt,
 		},
 		//#endregion
 
-	}
-
-	commandNotAdmin = "Your Discord ID must be listed as an admin in the `discord.json` settings for this bot to use this command."
-
-	commandHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
-
-		"help": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{Content: "wip"},
-			})
+		//#region NASA APOD
+		{
+			Name:        "apod-new",
+			Description: "Add a new feed",
+			Options:     append(genericCommandOpts, apodOpts...),
 		},
-		"ping": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			beforePong := time.Now()
-			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "Pong!",
+		{
+			Name:        "apod-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "apod-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, apodOpts...),
+		},
+		{
+			Name:        "apod-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "apod-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Plex Servers
+		{
+			Name:        "plex-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "url",
+				Description: "Plex Server",
+				Required:    true,
+			}}, genericCommandOpts...), plexOpts[1:]...),
+		},
+		{
+			Name:        "plex-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "plex-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, plexOpts...),
+		},
+		{
+			Name:        "plex-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "plex-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region RSS Feeds
+		{
+			Name:        "rss-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "url",
+				Description: "RSS Feed URL",
+				Required:    true,
+			}}, genericCommandOpts...), []*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "twitter",
+				Description: "Twitter for Username & Avatar",
+				Required:    false,
+			}}...),
+		},
+		{
+			Name:        "rss-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "rss-modify",
+			Description: "Modify an existing feed",
+			Options: append(genericCommandOpts, []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "change-url",
+					Description: "Modify RSS Feed URL",
+					Required:    false,
 				},
-			})
-			if err == nil {
-				afterPong := time.Now()
-				latency := discord.HeartbeatLatency().Milliseconds()
-				roundtrip := afterPong.Sub(beforePong).Milliseconds()
-				content := fmt.Sprintf("**Latency:** ``%dms`` — **Roundtrip:** ``%dms``",
-					latency,
-					roundtrip,
-				)
-				//TODO: embed?
-				s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-					Content: &content,
-				})
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "blacklist-url",
+					Description: "Add Blacklist Line (sep by \"|\") for URL contents",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "twitter",
+					Description: "Twitter for Username & Avatar",
+					Required:    false,
+				},
+			}...),
+		},
+		{
+			Name:        "rss-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "rss-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Spotify Artists
+		{
+			Name:        "spotify-artist-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "artist",
+				Description: "Spotify Artist (ID or Link)",
+				Required:    true,
+			}}, genericCommandOpts...), spotifyArtistOpts[1:]...),
+		},
+		{
+			Name:        "spotify-artist-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "spotify-artist-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, spotifyArtistOpts...),
+		},
+		{
+			Name:        "spotify-artist-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "spotify-artist-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Spotify Playlists
+		{
+			Name:        "spotify-playlist-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "playlist",
+				Description: "Spotify Playlist (ID or Link)",
+				Required:    true,
+			}}, genericCommandOpts...), spotifyPlaylistOpts[1:]...),
+		},
+		{
+			Name:        "spotify-playlist-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "spotify-playlist-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, spotifyPlaylistOpts...),
+		},
+		{
+			Name:        "spotify-playlist-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "spotify-playlist-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Spotify Podcasts
+		{
+			Name:        "spotify-podcast-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "show",
+				Description: "Spotify Show (ID or Link)",
+				Required:    true,
+			}}, genericCommandOpts...), spotifyPodcastOpts[1:]...),
+		},
+		{
+			Name:        "spotify-podcast-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "spotify-podcast-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, spotifyPodcastOpts...),
+		},
+		{
+			Name:        "spotify-podcast-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "spotify-podcast-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Twitch Live
+		{
+			Name:        "twitch-live-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "channel",
+				Description: "Twitch Channel",
+				Required:    true,
+			}}, genericCommandOpts...), twitchLiveOpts[1:]...),
+		},
+		{
+			Name:        "twitch-live-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "twitch-live-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, twitchLiveOpts...),
+		},
+		{
+			Name:        "twitch-live-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "twitch-live-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Twitter Accounts
+		{
+			Name:        "twitter-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "handle",
+				Description: "Twitter Handle (@)",
+				Required:    true,
+			}}, genericCommandOpts...), twitterOpts...),
+		},
+		{
+			Name:        "twitter-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "twitter-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, twitterOpts...),
+		},
+		{
+			Name:        "twitter-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "twitter-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+		//#region Twitter Trends
+		{
+			Name:        "twitter-trends-new",
+			Description: "Add a new feed",
+			Options: append(append([]*discordgo.ApplicationCommandOption{{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "location",
+				Description: "Location, WOEID or Place Name (e.g. Worldwide, Japan, London)",
+				Required:    true,
+			}}, genericCommandOpts...), twitterTrendsOpts[1:]...),
+		},
+		{
+			Name:        "twitter-trends-add",
+			Description: "Add this channel to an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "twitter-trends-modify",
+			Description: "Modify an existing feed",
+			Options:     append(genericCommandOpts, twitterTrendsOpts...),
+		},
+		{
+			Name:        "twitter-trends-delete",
+			Description: "Delete an existing feed",
+			Options:     nameCommandOpt,
+		},
+		{
+			Name:        "twitter-trends-show",
+			Description: "Display info for an existing feed",
+			Options:     nameCommandOpt,
+		},
+		//#endregion
+
+	}
+
+	commandNotAdmin = "Your Discord ID must be listed as an admin in the `discord.json` settings for this bot to use this command."
+
+	commandHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
+
+		"help": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: "wip"},
+			})
+		},
+		"ping": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			beforePong := time.Now()
+			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "Pong!",
+				},
+			})
+			if err == nil {
+				afterPong := time.Now()
+				latency := discord.HeartbeatLatency().Milliseconds()
+				roundtrip := afterPong.Sub(beforePong).Milliseconds()
+				content := fmt.Sprintf("**Latency:** ``%dms`` — **Roundtrip:** ``%dms``",
+					latency,
+					roundtrip,
+				)
+				//TODO: embed?
+				s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+					Content: &content,
+				})
+			}
+		},
+		"info": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			output := "**" + projectLabel + " " + projectVersion + "**"
+			output += "\n• discordgo v" + discordgo.VERSION + " with Discord API v" + discordgo.APIVersion
+			output += "\n_Launched " + humanize.Time(timeLaunched) + "_"
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: output,
+				},
+			})
+		},
+		"status": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: "wip"},
+			})
+			//TODO: everything
+		},
+		"feeds": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{Content: commandNotAdmin},
+				})
+			} else {
+				options := i.ApplicationCommandData().Options
+				optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+				for _, opt := range options {
+					optionMap[opt.Name] = opt
+				}
+
+				filter := -1
+
+				if opt, ok := optionMap["filter"]; ok {
+					filter = int(opt.IntValue())
+				}
+
+				output := ""
+				for _, feedThread := range getFeedsSnapshot() {
+					if filter != -1 {
+						if feedThread.Group != filter {
+							continue
+						}
+					}
+					output += fmt.Sprintf("\n• %s: `%s` **[%s]** \t\t_Last ran %s < %d time%s, %s >_",
+						getFeedTypeName(feedThread.Group), feedThread.Name, getFeedState(feedThread),
+						humanizeTimeOrNever(feedThread.LastRan), feedThread.TimesRan, ssuff(feedThread.TimesRan),
+						getFeedIntervalLabel(feedThread),
+					)
+					if feedThread.Failures > 0 {
+						output += fmt.Sprintf("\n  ↳ _%d failure%s in a row, last %s:_ `%s`",
+							feedThread.Failures, ssuff(feedThread.Failures), humanize.Time(feedThread.LastErrorAt), truncateText(feedThread.LastError, feedErrorPreviewLength))
+					}
+				}
+				if output == "" {
+					output = "No feeds..."
+				}
+				InteractionRespondPages(output, s, i)
+			}
+		},
+
+		"feed": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				options := i.ApplicationCommandData().Options
+				if len(options) == 0 {
+					return
+				}
+				subcommand := options[0]
+				optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+				for _, opt := range subcommand.Options {
+					optionMap[opt.Name] = opt
+				}
+
+				name := ""
+				if opt, ok := optionMap["name"]; ok {
+					name = opt.StringValue()
+				}
+				group := -1
+				if opt, ok := optionMap["type"]; ok {
+					group = int(opt.IntValue())
+				}
+				matches := findFeeds(name, group)
+				if len(matches) == 0 {
+					InteractionRespond("No feed exists with that name...", s, i)
+					return
+				} else if len(matches) > 1 {
+					InteractionRespond("More than one feed has that name, specify the type...", s, i)
+					return
+				}
+				feed := matches[0]
+				label := fmt.Sprintf("%s `%s`", getFeedTypeName(feed.Group), feed.Name)
+
+				switch subcommand.Name {
+				case "pause":
+					if err := setFeedEnabled(feed, false); err != nil {
+						InteractionRespond("Error pausing feed: "+err.Error(), s, i)
+					} else {
+						InteractionRespond("Paused "+label+", it won't run until resumed.", s, i)
+					}
+				case "resume":
+					if err := setFeedEnabled(feed, true); err != nil {
+						InteractionRespond("Error resuming feed: "+err.Error(), s, i)
+					} else {
+						InteractionRespond("Resumed "+label+"!", s, i)
+					}
+				case "run":
+					if !runFeedNow(feed) {
+						InteractionRespond(label+" is already running...", s, i)
+					} else {
+						InteractionRespond("Running "+label+" now...", s, i)
+					}
+				}
+			}
+		},
+
+		//#region MODULE MANAGEMENT COMMANDS
+
+		//#region Flickr Groups
+		"flickr-group-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleFlickrFeed
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["group"]; ok {
+					newFeed.ID = opt.StringValue()
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.ID == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsFlickrConfig(newFeed.Name, feedFlickrGroup) {
+					InteractionRespond("Flickr Group already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleFlickrCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				flickrConfig.Groups = append(flickrConfig.Groups, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedFlickrGroup, newFeed, "Added new Flickr Group! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedFlickrGroup)))
+				}
+
+				// Start new feed
+				spawnFeed(newFlickrFeedThread(newFeed, feedFlickrGroup))
+			}
+		},
+		"flickr-group-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsFlickrConfig(name, feedFlickrGroup) {
+						InteractionRespond("No Flickr Group exists with that name...", s, i)
+						return
+					} else {
+						config := getFlickrConfig(name, feedFlickrGroup) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateFlickrConfig(config.Name, feedFlickrGroup, *config)
+						if err := saveModuleConfigReply(feedFlickrGroup, *config, "Modified Flickr Group! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedFlickrGroup)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedFlickrGroup, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedFlickrGroup), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"flickr-group-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsFlickrConfig(feedName, feedFlickrGroup) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getFlickrConfig(feedName, feedFlickrGroup) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleFlickrCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateFlickrConfig(config.Name, feedFlickrGroup, *config)
+						if err := saveModuleConfigReply(feedFlickrGroup, *config, "Modified Flickr Group! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedFlickrGroup)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedFlickrGroup, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedFlickrGroup), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"flickr-group-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsFlickrConfig(name, feedFlickrGroup) {
+						InteractionRespond("No Flickr Group exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteFlickrConfig(name, feedFlickrGroup); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedFlickrGroup); err != nil {
+							InteractionRespond("Error saving Flickr Group config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"flickr-group-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsFlickrConfig(name, feedFlickrGroup) {
+						InteractionRespond("No Flickr Group exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedFlickrGroup)
+						reply := fmt.Sprintf("**Flickr Group: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getFlickrConfig(name, feedFlickrGroup)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
+		//#region Flickr Users
+		"flickr-user-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleFlickrFeed
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["user"]; ok {
+					newFeed.ID = opt.StringValue()
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.ID == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsFlickrConfig(newFeed.Name, feedFlickrUser) {
+					InteractionRespond("Flickr User already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleFlickrCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				flickrConfig.Users = append(flickrConfig.Users, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedFlickrUser, newFeed, "Added new Flickr User! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedFlickrUser)))
+				}
+
+				// Start new feed
+				spawnFeed(newFlickrFeedThread(newFeed, feedFlickrUser))
+			}
+		},
+		"flickr-user-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsFlickrConfig(name, feedFlickrUser) {
+						InteractionRespond("No Flickr User exists with that name...", s, i)
+						return
+					} else {
+						config := getFlickrConfig(name, feedFlickrUser) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateFlickrConfig(config.Name, feedFlickrUser, *config)
+						if err := saveModuleConfigReply(feedFlickrUser, *config, "Modified Flickr User! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedFlickrUser)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedFlickrUser, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedFlickrUser), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"flickr-user-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsFlickrConfig(feedName, feedFlickrUser) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getFlickrConfig(feedName, feedFlickrUser) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleFlickrCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateFlickrConfig(config.Name, feedFlickrUser, *config)
+						if err := saveModuleConfigReply(feedFlickrUser, *config, "Modified Flickr User! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedFlickrUser)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedFlickrUser, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedFlickrUser), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"flickr-user-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsFlickrConfig(name, feedFlickrUser) {
+						InteractionRespond("No Flickr User exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteFlickrConfig(name, feedFlickrUser); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedFlickrUser); err != nil {
+							InteractionRespond("Error saving Flickr User config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"flickr-user-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsFlickrConfig(name, feedFlickrUser) {
+						InteractionRespond("No Flickr User exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedFlickrUser)
+						reply := fmt.Sprintf("**Flickr User: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getFlickrConfig(name, feedFlickrUser)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
+		//#region Instagram Accounts
+		"instagram-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleInstagramAcc
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["handle"]; ok {
+					newFeed.Handle = strings.TrimPrefix(opt.StringValue(), "@")
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.Handle == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsInstagramAccConfig(newFeed.Name) {
+					InteractionRespond("Instagram Account already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleInstagramAccCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				instagramConfig.Accounts = append(instagramConfig.Accounts, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedInstagramAccount, newFeed, "Added new Instagram Account! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedInstagramAccount)))
+				}
+
+				// Start new feed
+				spawnFeed(newInstagramAccFeedThread(newFeed))
+			}
+		},
+		"instagram-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsInstagramAccConfig(name) {
+						InteractionRespond("No Instagram Account exists with that name...", s, i)
+						return
+					} else {
+						config := getInstagramAccConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateInstagramAccConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedInstagramAccount, *config, "Modified Instagram Account! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedInstagramAccount)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedInstagramAccount, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedInstagramAccount), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"instagram-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsInstagramAccConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getInstagramAccConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleInstagramAccCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateInstagramAccConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedInstagramAccount, *config, "Modified Instagram Account! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedInstagramAccount)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedInstagramAccount, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedInstagramAccount), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"instagram-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsInstagramAccConfig(name) {
+						InteractionRespond("No Instagram Account exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteInstagramAccConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedInstagramAccount); err != nil {
+							InteractionRespond("Error saving Instagram Account config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"instagram-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsInstagramAccConfig(name) {
+						InteractionRespond("No Instagram Account exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedInstagramAccount)
+						reply := fmt.Sprintf("**Instagram Account: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getInstagramAccConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
+		//#region NASA APOD
+		"apod-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleAPODFeed
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" {
+					InteractionRespond("Config name was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsAPODConfig(newFeed.Name) {
+					InteractionRespond("APOD Feed already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleAPODCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				apodConfig.Feeds = append(apodConfig.Feeds, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedAPOD, newFeed, "Added new APOD Feed! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedAPOD)))
+				}
+
+				// Start new feed
+				spawnFeed(newAPODFeedThread(newFeed))
+			}
+		},
+		"apod-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsAPODConfig(name) {
+						InteractionRespond("No APOD Feed exists with that name...", s, i)
+						return
+					} else {
+						config := getAPODConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateAPODConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedAPOD, *config, "Modified APOD Feed! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedAPOD)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedAPOD, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedAPOD), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"apod-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsAPODConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getAPODConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleAPODCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateAPODConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedAPOD, *config, "Modified APOD Feed! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedAPOD)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedAPOD, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedAPOD), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"apod-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsAPODConfig(name) {
+						InteractionRespond("No APOD Feed exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteAPODConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedAPOD); err != nil {
+							InteractionRespond("Error saving APOD Feed config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"apod-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsAPODConfig(name) {
+						InteractionRespond("No APOD Feed exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedAPOD)
+						reply := fmt.Sprintf("**APOD Feed: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getAPODConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
+		//#region Plex Servers
+		"plex-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModulePlexServer
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["url"]; ok {
+					newFeed.URL = strings.TrimRight(opt.StringValue(), "/")
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.URL == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsPlexServerConfig(newFeed.Name) {
+					InteractionRespond("Plex Server already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handlePlexServerCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				plexConfig.Servers = append(plexConfig.Servers, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedPlexServer, redactPlexConfig(newFeed), "Added new Plex Server! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedPlexServer)))
+				}
+
+				// Start new feed
+				spawnFeed(newPlexServerFeedThread(newFeed))
+			}
+		},
+		"plex-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsPlexServerConfig(name) {
+						InteractionRespond("No Plex Server exists with that name...", s, i)
+						return
+					} else {
+						config := getPlexServerConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updatePlexServerConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedPlexServer, redactPlexConfig(*config), "Modified Plex Server! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedPlexServer)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedPlexServer, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedPlexServer), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"plex-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsPlexServerConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getPlexServerConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handlePlexServerCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updatePlexServerConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedPlexServer, redactPlexConfig(*config), "Modified Plex Server! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedPlexServer)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedPlexServer, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedPlexServer), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"plex-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsPlexServerConfig(name) {
+						InteractionRespond("No Plex Server exists with that name...", s, i)
+						return
+					} else {
+						if err := deletePlexServerConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedPlexServer); err != nil {
+							InteractionRespond("Error saving Plex Server config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"plex-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsPlexServerConfig(name) {
+						InteractionRespond("No Plex Server exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedPlexServer)
+						reply := fmt.Sprintf("**Plex Server: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getPlexServerConfig(name)
+						if err := replyConfig(redactPlexConfig(*config), reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
+		//#region RSS Feeds
+		"rss-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleRssFeed
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["url"]; ok {
+					newFeed.URL = opt.StringValue()
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.URL == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsRssConfig(newFeed.Name) {
+					InteractionRespond("RSS Feed already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleRssCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				rssConfig.Feeds = append(rssConfig.Feeds, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedRSS, newFeed, "Added new RSS feed! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedRSS)))
+				}
+
+				// Start new feed
+				spawnFeed(newRssFeedThread(newFeed))
+			}
+		},
+		"rss-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsRssConfig(name) {
+						InteractionRespond("No RSS Feed exists with that name...", s, i)
+						return
+					} else {
+						config := getRssConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateRssConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedRSS, *config, "Modified RSS Feed! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedRSS)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedRSS, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedRSS), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"rss-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsRssConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getRssConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleRssCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateRssConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedRSS, *config, "Modified RSS Feed! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedRSS)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedRSS, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedRSS), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"rss-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsRssConfig(name) {
+						InteractionRespond("No RSS Feed exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteRssConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedRSS); err != nil {
+							InteractionRespond("Error saving RSS config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"rss-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsRssConfig(name) {
+						InteractionRespond("No RSS Feed exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedRSS)
+						reply := fmt.Sprintf("**RSS Feed: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getRssConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
+		//#region Spotify Artists
+		"spotify-artist-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleSpotifyArtist
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["artist"]; ok {
+					newFeed.ID = parseSpotifyID(opt.StringValue(), "artist")
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.ID == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsSpotifyArtistConfig(newFeed.Name) {
+					InteractionRespond("Spotify Artist already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleSpotifyArtistCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				spotifyConfig.Artists = append(spotifyConfig.Artists, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedSpotifyArtist, newFeed, "Added new Spotify Artist! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyArtist)))
+				}
+
+				// Start new feed
+				spawnFeed(newSpotifyArtistFeedThread(newFeed))
+			}
+		},
+		"spotify-artist-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsSpotifyArtistConfig(name) {
+						InteractionRespond("No Spotify Artist exists with that name...", s, i)
+						return
+					} else {
+						config := getSpotifyArtistConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateSpotifyArtistConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedSpotifyArtist, *config, "Modified Spotify Artist! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyArtist)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedSpotifyArtist, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedSpotifyArtist), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"spotify-artist-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsSpotifyArtistConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getSpotifyArtistConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleSpotifyArtistCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateSpotifyArtistConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedSpotifyArtist, *config, "Modified Spotify Artist! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyArtist)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedSpotifyArtist, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedSpotifyArtist), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"spotify-artist-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsSpotifyArtistConfig(name) {
+						InteractionRespond("No Spotify Artist exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteSpotifyArtistConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedSpotifyArtist); err != nil {
+							InteractionRespond("Error saving Spotify Artist config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"spotify-artist-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsSpotifyArtistConfig(name) {
+						InteractionRespond("No Spotify Artist exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedSpotifyArtist)
+						reply := fmt.Sprintf("**Spotify Artist: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getSpotifyArtistConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
 			}
 		},
-		"info": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			output := "**" + projectLabel + " " + projectVersion + "**"
-			output += "\n• discordgo v" + discordgo.VERSION + " with Discord API v" + discordgo.APIVersion
-			output += "\n_Launched " + humanize.Time(timeLaunched) + "_"
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: output,
-				},
-			})
+		//#endregion
+
+		//#region Spotify Playlists
+		"spotify-playlist-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleSpotifyPlaylist
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["playlist"]; ok {
+					newFeed.ID = parseSpotifyID(opt.StringValue(), "playlist")
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.ID == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsSpotifyPlaylistConfig(newFeed.Name) {
+					InteractionRespond("Spotify Playlist already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleSpotifyPlaylistCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				spotifyConfig.Playlists = append(spotifyConfig.Playlists, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedSpotifyPlaylist, newFeed, "Added new Spotify Playlist! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyPlaylist)))
+				}
+
+				// Start new feed
+				spawnFeed(newSpotifyPlaylistFeedThread(newFeed))
+			}
 		},
-		"status": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{Content: "wip"},
-			})
-			//TODO: everything
+		"spotify-playlist-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsSpotifyPlaylistConfig(name) {
+						InteractionRespond("No Spotify Playlist exists with that name...", s, i)
+						return
+					} else {
+						config := getSpotifyPlaylistConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateSpotifyPlaylistConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedSpotifyPlaylist, *config, "Modified Spotify Playlist! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyPlaylist)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedSpotifyPlaylist, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedSpotifyPlaylist), config.Name))
+						}
+					}
+				}
+			}
 		},
-		"feeds": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		"spotify-playlist-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
 			}
 			if !isBotAdmin(authorUser.ID) {
-				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{Content: commandNotAdmin},
-				})
+				InteractionRespond(commandNotAdmin, s, i)
 			} else {
-				options := i.ApplicationCommandData().Options
-				optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
-				for _, opt := range options {
-					optionMap[opt.Name] = opt
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsSpotifyPlaylistConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getSpotifyPlaylistConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleSpotifyPlaylistCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateSpotifyPlaylistConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedSpotifyPlaylist, *config, "Modified Spotify Playlist! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyPlaylist)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedSpotifyPlaylist, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedSpotifyPlaylist), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"spotify-playlist-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsSpotifyPlaylistConfig(name) {
+						InteractionRespond("No Spotify Playlist exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteSpotifyPlaylistConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedSpotifyPlaylist); err != nil {
+							InteractionRespond("Error saving Spotify Playlist config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"spotify-playlist-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsSpotifyPlaylistConfig(name) {
+						InteractionRespond("No Spotify Playlist exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedSpotifyPlaylist)
+						reply := fmt.Sprintf("**Spotify Playlist: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getSpotifyPlaylistConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
 				}
+			}
+		},
+		//#endregion
 
-				filter := -1
+		//#region Spotify Podcasts
+		"spotify-podcast-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleSpotifyPodcast
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["show"]; ok {
+					newFeed.ID = parseSpotifyID(opt.StringValue(), "show")
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.ID == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsSpotifyPodcastConfig(newFeed.Name) {
+					InteractionRespond("Spotify Podcast already exists with that name...", s, i)
+					return
+				}
 
-				if opt, ok := optionMap["filter"]; ok {
-					filter = int(opt.IntValue())
+				// Handle Options
+				if err := handleSpotifyPodcastCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
 				}
 
-				output := ""
-				for _, feedThread := range feeds {
-					if filter != -1 {
-						if feedThread.Group != filter {
-							continue
+				// Finalize
+				spotifyConfig.Podcasts = append(spotifyConfig.Podcasts, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedSpotifyPodcast, newFeed, "Added new Spotify Podcast! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyPodcast)))
+				}
+
+				// Start new feed
+				spawnFeed(newSpotifyPodcastFeedThread(newFeed))
+			}
+		},
+		"spotify-podcast-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsSpotifyPodcastConfig(name) {
+						InteractionRespond("No Spotify Podcast exists with that name...", s, i)
+						return
+					} else {
+						config := getSpotifyPodcastConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateSpotifyPodcastConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedSpotifyPodcast, *config, "Modified Spotify Podcast! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyPodcast)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedSpotifyPodcast, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedSpotifyPodcast), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"spotify-podcast-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsSpotifyPodcastConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getSpotifyPodcastConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleSpotifyPodcastCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateSpotifyPodcastConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedSpotifyPodcast, *config, "Modified Spotify Podcast! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedSpotifyPodcast)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedSpotifyPodcast, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedSpotifyPodcast), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"spotify-podcast-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsSpotifyPodcastConfig(name) {
+						InteractionRespond("No Spotify Podcast exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteSpotifyPodcastConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedSpotifyPodcast); err != nil {
+							InteractionRespond("Error saving Spotify Podcast config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
 						}
 					}
-					output += fmt.Sprintf("\n• %s: `%s` \t\t_Last ran %s < %d time%s, every %d minute%s >_",
-						getFeedTypeName(feedThread.Group), feedThread.Name,
-						humanize.Time(feedThread.LastRan), feedThread.TimesRan, ssuff(feedThread.TimesRan),
-						feedThread.WaitMins, ssuff(feedThread.WaitMins),
-					)
 				}
-				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{
-						Content: output,
-					},
-				})
 			}
 		},
-
-		//#region MODULE MANAGEMENT COMMANDS
-
-		//#region Instagram Accounts
-		"instagram-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		"spotify-podcast-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
 			}
 			if !isBotAdmin(authorUser.ID) {
-				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{Content: commandNotAdmin},
-				})
+				InteractionRespond(commandNotAdmin, s, i)
 			} else {
-				options := i.ApplicationCommandData().Options
-				optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
-				for _, opt := range options {
-					optionMap[opt.Name] = opt
-				}
-
-				var newFeed configModuleInstagramAcc
-				newFeed.Destinations = []string{i.ChannelID}
-				if opt, ok := optionMap["handle"]; ok {
-					newFeed.ID = opt.StringValue()
-				}
+				optionMap := interactionOptMap(i)
 				if opt, ok := optionMap["name"]; ok {
-					newFeed.Name = opt.StringValue()
-				}
-				// Doesn't exist
-				if existsInstagramConfig(newFeed.Name) {
-					s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-						Type: discordgo.InteractionResponseChannelMessageWithSource,
-						Data: &discordgo.InteractionResponseData{Content: "Instagram Account already exists with that name..."},
-					})
-					return
-				}
-				if newFeed.Name == "" || newFeed.ID == "" {
-					s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-						Type: discordgo.InteractionResponseChannelMessageWithSource,
-						Data: &discordgo.InteractionResponseData{Content: "Config name or feed identifier was empty... Try again!"},
-					})
-					return
-				}
-				if opt, ok := optionMap["wait"]; ok {
-					val := int(opt.IntValue())
-					newFeed.WaitMins = &val
-				}
-
-				feedIndex := len(instagramConfig.Accounts) // cache index for new routine
-				instagramConfig.Accounts = append(instagramConfig.Accounts, newFeed)
-
-				err := saveModuleConfig(feedInstagramAccount)
-				if err != nil {
-					log.Println(color.HiRedString("error saving config")) //TODO:
-				} else {
-					reply := "Added new Instagram Account! Saved to config..."
-					json, err := json.MarshalIndent(newFeed, "", "\t")
-					if err == nil {
-						reply += fmt.Sprintf("\n```json\n%s```", json)
+					name := opt.StringValue()
+					if !existsSpotifyPodcastConfig(name) {
+						InteractionRespond("No Spotify Podcast exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedSpotifyPodcast)
+						reply := fmt.Sprintf("**Spotify Podcast: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getSpotifyPodcastConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
 					}
-					s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-						Type: discordgo.InteractionResponseChannelMessageWithSource,
-						Data: &discordgo.InteractionResponseData{Content: reply},
-					})
-				}
-
-				waitMins := instagramConfig.WaitMins
-				if newFeed.WaitMins != nil {
-					waitMins = *newFeed.WaitMins
 				}
-
-				feeds = append(feeds, feedThread{
-					Group:    feedInstagramAccount,
-					Name:     newFeed.Name,
-					Ref:      newFeed.ID,
-					Config:   newFeed,
-					WaitMins: waitMins,
-				})
-				go startFeed(&feeds[feedIndex])
 			}
 		},
-		"instagram-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			//TODO: everything
-			InteractionRespond("wip", s, i)
-		},
-		"instagram-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			//TODO: everything
-			InteractionRespond("wip", s, i)
-		},
-		"instagram-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			//TODO: everything
-			InteractionRespond("wip", s, i)
-		},
-		"instagram-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			//TODO: everything
-			InteractionRespond("wip", s, i)
-		},
 		//#endregion
 
-		//#region RSS Feeds
-		"rss-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		//#region Twitch Live
+		"twitch-live-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
@@ -490,51 +2687,42 @@ var (
 			} else {
 				optionMap := interactionOptMap(i)
 				// New Feed
-				var newFeed configModuleRssFeed
+				var newFeed configModuleTwitchChannel
 				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
-				if opt, ok := optionMap["url"]; ok {
-					newFeed.URL = opt.StringValue()
+				if opt, ok := optionMap["channel"]; ok {
+					newFeed.Login = parseTwitchLogin(opt.StringValue())
 				}
 				if opt, ok := optionMap["name"]; ok {
 					newFeed.Name = opt.StringValue()
 				}
 				// Identifiers are empty
-				if newFeed.Name == "" || newFeed.URL == "" {
+				if newFeed.Name == "" || newFeed.Login == "" {
 					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
 					return
 				}
 				// Doesn't exist
-				if existsRssConfig(newFeed.Name) {
-					InteractionRespond("RSS Feed already exists with that name...", s, i)
+				if existsTwitchLiveConfig(newFeed.Name) {
+					InteractionRespond("Twitch Channel already exists with that name...", s, i)
 					return
 				}
 
 				// Handle Options
-				handleRssCmdOpts(&newFeed, optionMap, s, i)
+				if err := handleTwitchLiveCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
 
 				// Finalize
-				rssConfig.Feeds = append(rssConfig.Feeds, newFeed) // add new feed to config
-				if err := saveModuleConfigReply(feedRSS, newFeed, "Added new RSS feed! Saved to config...", s, i); err != nil {
-					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedRSS)))
+				twitchConfig.Channels = append(twitchConfig.Channels, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedTwitchLive, newFeed, "Added new Twitch Channel! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedTwitchLive)))
 				}
 
 				// Start new feed
-				waitMins := rssConfig.WaitMins
-				if newFeed.WaitMins != nil {
-					waitMins = *newFeed.WaitMins
-				}
-				feedIndex := len(feeds)
-				feeds = append(feeds, feedThread{
-					Group:    feedRSS,
-					Name:     newFeed.Name,
-					Ref:      "\"" + newFeed.URL + "\"",
-					Config:   newFeed,
-					WaitMins: waitMins,
-				})
-				go startFeed(&feeds[feedIndex])
+				spawnFeed(newTwitchLiveFeedThread(newFeed))
 			}
 		},
-		"rss-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		"twitch-live-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
@@ -546,27 +2734,27 @@ var (
 				if opt, ok := optionMap["name"]; ok {
 					name := opt.StringValue()
 
-					if !existsRssConfig(name) {
-						InteractionRespond("No RSS Feed exists with that name...", s, i)
+					if !existsTwitchLiveConfig(name) {
+						InteractionRespond("No Twitch Channel exists with that name...", s, i)
 						return
 					} else {
-						config := getRssConfig(name) // point to it so it modifies source
+						config := getTwitchLiveConfig(name) // point to it so it modifies source
 						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
 
 						// Save
-						updateRssConfig(config.Name, *config)
-						if err := saveModuleConfigReply(feedRSS, *config, "Modified RSS Feed! Saved to config...", s, i); err != nil {
-							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedRSS)))
+						updateTwitchLiveConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedTwitchLive, *config, "Modified Twitch Channel! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedTwitchLive)))
 						}
 						// Update Live
-						if !updateFeedConfig(config.Name, feedRSS, *config) {
-							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedRSS), config.Name))
+						if !updateFeedConfig(config.Name, feedTwitchLive, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedTwitchLive), config.Name))
 						}
 					}
 				}
 			}
 		},
-		"rss-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		"twitch-live-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
@@ -580,29 +2768,32 @@ var (
 					return
 				} else {
 					feedName := opt.StringValue()
-					if !existsRssConfig(feedName) {
+					if !existsTwitchLiveConfig(feedName) {
 						InteractionRespond("No feed config exists with that name...", s, i)
 						return
 					} else {
-						config := getRssConfig(feedName) // point to it so it modifies source
+						config := getTwitchLiveConfig(feedName) // point to it so it modifies source
 
 						// Handle Options
-						handleRssCmdOpts(config, optionMap, s, i)
+						if err := handleTwitchLiveCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
 
 						// Save
-						updateRssConfig(config.Name, *config)
-						if err := saveModuleConfigReply(feedRSS, *config, "Modified RSS Feed! Saved to config...", s, i); err != nil {
-							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedRSS)))
+						updateTwitchLiveConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedTwitchLive, *config, "Modified Twitch Channel! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedTwitchLive)))
 						}
 						// Update Live
-						if !updateFeedConfig(config.Name, feedRSS, *config) {
-							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedRSS), config.Name))
+						if !updateFeedConfig(config.Name, feedTwitchLive, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedTwitchLive), config.Name))
 						}
 					}
 				}
 			}
 		},
-		"rss-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		"twitch-live-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
@@ -613,17 +2804,17 @@ var (
 				optionMap := interactionOptMap(i)
 				if opt, ok := optionMap["name"]; ok {
 					name := opt.StringValue()
-					if !existsRssConfig(name) {
-						InteractionRespond("No RSS Feed exists with that name...", s, i)
+					if !existsTwitchLiveConfig(name) {
+						InteractionRespond("No Twitch Channel exists with that name...", s, i)
 						return
 					} else {
-						if err := deleteRssConfig(name); err != nil {
+						if err := deleteTwitchLiveConfig(name); err != nil {
 							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
 							return
 						}
 						// Save
-						if err := saveModuleConfig(feedRSS); err != nil {
-							InteractionRespond("Error saving RSS config: "+err.Error(), s, i)
+						if err := saveModuleConfig(feedTwitchLive); err != nil {
+							InteractionRespond("Error saving Twitch Channel config: "+err.Error(), s, i)
 						} else {
 							InteractionRespond("Successfully deleted feed!", s, i)
 						}
@@ -631,7 +2822,7 @@ var (
 				}
 			}
 		},
-		"rss-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		"twitch-live-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			authorUser := getAuthor(i)
 			if authorUser == nil {
 				return
@@ -642,15 +2833,20 @@ var (
 				optionMap := interactionOptMap(i)
 				if opt, ok := optionMap["name"]; ok {
 					name := opt.StringValue()
-					if !existsRssConfig(name) {
-						InteractionRespond("No RSS Feed exists with that name...", s, i)
+					if !existsTwitchLiveConfig(name) {
+						InteractionRespond("No Twitch Channel exists with that name...", s, i)
 						return
 					} else {
-						feed := getModuleFeed(name, feedRSS)
-						reply := fmt.Sprintf("**RSS Feed: %s**", feed.Name)
-						reply += fmt.Sprintf("\n_Ran %s, runs every %d minutes, ran %d time%s since launch_",
-							humanize.Time(feed.LastRan), feed.WaitMins, feed.TimesRan, ssuff(feed.TimesRan))
-						config := getRssConfig(name)
+						feed := getModuleFeed(name, feedTwitchLive)
+						reply := fmt.Sprintf("**Twitch Channel: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getTwitchLiveConfig(name)
 						if err := replyConfig(*config, reply, s, i); err != nil {
 							log.Println(color.HiRedString("Error replying: %s", err.Error()))
 						}
@@ -715,7 +2911,10 @@ var (
 				}
 
 				// Handle Options
-				handleTwitterAccCmdOpts(&newFeed, optionMap, s, i)
+				if err := handleTwitterAccCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
 
 				// Finalize
 				twitterConfig.Accounts = append(twitterConfig.Accounts, newFeed) // add new feed to config
@@ -724,19 +2923,7 @@ var (
 				}
 
 				// Start new feed
-				waitMins := twitterConfig.WaitMins
-				if newFeed.WaitMins != nil {
-					waitMins = *newFeed.WaitMins
-				}
-				feedIndex := len(feeds)
-				feeds = append(feeds, feedThread{
-					Group:    feedTwitterAccount,
-					Name:     newFeed.Name,
-					Ref:      newFeed.Handle,
-					Config:   newFeed,
-					WaitMins: waitMins,
-				})
-				go startFeed(&feeds[feedIndex])
+				spawnFeed(newTwitterAccFeedThread(newFeed))
 			}
 		},
 		"twitter-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -792,7 +2979,10 @@ var (
 						config := getTwitterAccConfig(feedName) // point to it so it modifies source
 
 						// Handle Options
-						handleTwitterAccCmdOpts(config, optionMap, s, i)
+						if err := handleTwitterAccCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
 
 						// Save
 						updateTwitterAccConfig(config.Name, *config)
@@ -852,9 +3042,14 @@ var (
 						return
 					} else {
 						feed := getModuleFeed(name, feedTwitterAccount)
-						reply := fmt.Sprintf("**Twitter Account: %s**", feed.Name)
-						reply += fmt.Sprintf("\n_Ran %s, runs every %d minutes, ran %d time%s since launch_",
-							humanize.Time(feed.LastRan), feed.WaitMins, feed.TimesRan, ssuff(feed.TimesRan))
+						reply := fmt.Sprintf("**Twitter Account: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
 						config := getTwitterAccConfig(name)
 						if err := replyConfig(*config, reply, s, i); err != nil {
 							log.Println(color.HiRedString("Error replying: %s", err.Error()))
@@ -867,6 +3062,188 @@ var (
 		},
 		//#endregion
 
+		//#region Twitter Trends
+		"twitter-trends-new": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				// New Feed
+				var newFeed configModuleTwitterTrends
+				newFeed.Destinations = []feedDestination{{Channel: i.ChannelID}}
+				if opt, ok := optionMap["location"]; ok {
+					newFeed.Location = opt.StringValue()
+				}
+				if opt, ok := optionMap["name"]; ok {
+					newFeed.Name = opt.StringValue()
+				}
+				// Identifiers are empty
+				if newFeed.Name == "" || newFeed.Location == "" {
+					InteractionRespond("Config name or feed identifier was empty... Try again!", s, i)
+					return
+				}
+				// Doesn't exist
+				if existsTwitterTrendsConfig(newFeed.Name) {
+					InteractionRespond("Twitter Trends Feed already exists with that name...", s, i)
+					return
+				}
+
+				// Handle Options
+				if err := handleTwitterTrendsCmdOpts(&newFeed, optionMap, s, i); err != nil {
+					InteractionRespond("Error handling options: "+err.Error(), s, i)
+					return
+				}
+
+				// Finalize
+				twitterConfig.Trends = append(twitterConfig.Trends, newFeed) // add new feed to config
+				if err := saveModuleConfigReply(feedTwitterTrends, newFeed, "Added new Twitter Trends Feed! Saved to config...", s, i); err != nil {
+					log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedTwitterTrends)))
+				}
+
+				// Start new feed
+				spawnFeed(newTwitterTrendsFeedThread(newFeed))
+			}
+		},
+		"twitter-trends-add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+
+					if !existsTwitterTrendsConfig(name) {
+						InteractionRespond("No Twitter Trends Feed exists with that name...", s, i)
+						return
+					} else {
+						config := getTwitterTrendsConfig(name) // point to it so it modifies source
+						config.Destinations = append(config.Destinations, feedDestination{Channel: i.ChannelID})
+
+						// Save
+						updateTwitterTrendsConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedTwitterTrends, *config, "Modified Twitter Trends Feed! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedTwitterTrends)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedTwitterTrends, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedTwitterTrends), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"twitter-trends-modify": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; !ok {
+					InteractionRespond("Config name identifier is empty... Try again!", s, i)
+					return
+				} else {
+					feedName := opt.StringValue()
+					if !existsTwitterTrendsConfig(feedName) {
+						InteractionRespond("No feed config exists with that name...", s, i)
+						return
+					} else {
+						config := getTwitterTrendsConfig(feedName) // point to it so it modifies source
+
+						// Handle Options
+						if err := handleTwitterTrendsCmdOpts(config, optionMap, s, i); err != nil {
+							InteractionRespond("Error handling options: "+err.Error(), s, i)
+							return
+						}
+
+						// Save
+						updateTwitterTrendsConfig(config.Name, *config)
+						if err := saveModuleConfigReply(feedTwitterTrends, *config, "Modified Twitter Trends Feed! Saved to config...", s, i); err != nil {
+							log.Println(color.HiRedString("failed to save config for %s...", getFeedTypeName(feedTwitterTrends)))
+						}
+						// Update Live
+						if !updateFeedConfig(config.Name, feedTwitterTrends, *config) {
+							log.Println(color.HiRedString("failed to update feed %s/%s...", getFeedTypeName(feedTwitterTrends), config.Name))
+						}
+					}
+				}
+			}
+		},
+		"twitter-trends-delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsTwitterTrendsConfig(name) {
+						InteractionRespond("No Twitter Trends Feed exists with that name...", s, i)
+						return
+					} else {
+						if err := deleteTwitterTrendsConfig(name); err != nil {
+							InteractionRespond("Error deleting feed: "+err.Error(), s, i)
+							return
+						}
+						// Save
+						if err := saveModuleConfig(feedTwitterTrends); err != nil {
+							InteractionRespond("Error saving Twitter Trends Feed config: "+err.Error(), s, i)
+						} else {
+							InteractionRespond("Successfully deleted feed!", s, i)
+						}
+					}
+				}
+			}
+		},
+		"twitter-trends-show": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			authorUser := getAuthor(i)
+			if authorUser == nil {
+				return
+			}
+			if !isBotAdmin(authorUser.ID) {
+				InteractionRespond(commandNotAdmin, s, i)
+			} else {
+				optionMap := interactionOptMap(i)
+				if opt, ok := optionMap["name"]; ok {
+					name := opt.StringValue()
+					if !existsTwitterTrendsConfig(name) {
+						InteractionRespond("No Twitter Trends Feed exists with that name...", s, i)
+						return
+					} else {
+						feed := getModuleFeed(name, feedTwitterTrends)
+						reply := fmt.Sprintf("**Twitter Trends Feed: %s** [%s]", feed.Name, getFeedState(*feed))
+						if feed.Failures > 0 {
+							reply += fmt.Sprintf("\n_%d failure%s in a row, last %s:_ `%s`",
+								feed.Failures, ssuff(feed.Failures), humanize.Time(feed.LastErrorAt), truncateText(feed.LastError, feedErrorPreviewLength))
+						}
+						reply += fmt.Sprintf("\n_Ran %s, runs %s, ran %d time%s, last new item %s_",
+							humanizeTimeOrNever(feed.LastRan), getFeedIntervalLabel(*feed), feed.TimesRan, ssuff(feed.TimesRan),
+							humanizeTimeOrNever(feed.LastNewItem))
+						config := getTwitterTrendsConfig(name)
+						if err := replyConfig(*config, reply, s, i); err != nil {
+							log.Println(color.HiRedString("Error replying: %s", err.Error()))
+						}
+						// Send
+						InteractionRespond(reply, s, i)
+					}
+				}
+			}
+		},
+		//#endregion
+
 		//#endregion
 	}
 )
@@ -931,3 +3308,34 @@ func InteractionRespond(content string, s *discordgo.Session, i *discordgo.Inter
 	})
 	return nil
 }
+
+// Responds with content too long for one message, split between lines into follow up messages.
+func InteractionRespondPages(content string, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var pages []string
+	page := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = truncateText(line, discordMessageLimit)
+		if page != "" && len([]rune(page))+1+len([]rune(line)) > discordMessageLimit {
+			pages = append(pages, page)
+			page = ""
+		}
+		if page != "" {
+			page += "\n"
+		}
+		page += line
+	}
+	pages = append(pages, page)
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: pages[0]},
+	}); err != nil {
+		return err
+	}
+	for _, page := range pages[1:] {
+		if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{Content: page}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
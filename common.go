@@ -2,14 +2,20 @@ package main
 
 import (
 	"fmt"
+	"hash/fnv"
 	"log"
+	"math/bits"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/hako/durafmt"
+	"golang.org/x/net/html"
 )
 
 //#region Program functions
@@ -104,4 +110,246 @@ func shortenTime(input string) string {
 	return input
 }
 
+// Keeps only characters that are safe in a file name on any OS.
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func humanizeTimeOrNever(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return humanize.Time(t)
+}
+
+var titleWordSplitter = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// 64-bit simhash of a title's words and word pairs, similar titles give hashes with a small hamming distance.
+// Returns 0 for titles too short to compare meaningfully.
+func titleSimhash(title string) uint64 {
+	var words []string
+	for _, word := range titleWordSplitter.Split(strings.ToLower(title), -1) {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) < 4 {
+		return 0
+	}
+	features := words
+	for k := 0; k < len(words)-1; k++ {
+		features = append(features, words[k]+" "+words[k+1])
+	}
+
+	var weights [64]int
+	for _, feature := range features {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(feature))
+		hash := hasher.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if hash&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var simhash uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			simhash |= 1 << bit
+		}
+	}
+	return simhash
+}
+
+func hammingDistance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+//#endregion
+
+//#region URL functions
+
+var (
+	// Query parameters that only exist for tracking and never change the content of a page.
+	urlTrackingParams = []string{
+		"fbclid", "gclid", "dclid", "gclsrc", "msclkid", "yclid", "twclid", "igshid",
+		"mc_cid", "mc_eid", "_hsenc", "_hsmi", "mkt_tok", "ref_src", "ref_url",
+		"cmpid", "ncid", "sr_share", "oly_anon_id", "oly_enc_id", "vero_id", "wt_mc",
+	}
+	urlTwitterHosts = []string{
+		"twitter.com", "mobile.twitter.com", "x.com", "mobile.x.com",
+		"fxtwitter.com", "vxtwitter.com", "fixupx.com",
+	}
+	urlTweetPath = regexp.MustCompile(`^/(?:[A-Za-z0-9_]+|i(?:/web)?)/status(?:es)?/([0-9]+)`)
+)
+
+// Unwraps redirect links such as Google Alerts ("google.com/url?...&url=X&ct=...") to the link they point to.
+func unwrapURL(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return link
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if strings.HasPrefix(host, "google.") && u.Path == "/url" {
+		for _, key := range []string{"url", "q"} {
+			if target := u.Query().Get(key); strings.HasPrefix(target, "http") {
+				return target
+			}
+		}
+	}
+	return link
+}
+
+func isTwitterHost(host string) bool {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	for _, twitterHost := range urlTwitterHosts {
+		if host == twitterHost {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonical link for a tweet, independent of handle or twitter.com/x.com mirrors.
+func canonicalTweetURL(id string) string {
+	return "https://twitter.com/i/status/" + id
+}
+
+// Normalizes a URL for use as a reference, so the same item is matched no matter which
+// tracking params, scheme or host casing it was linked with. Not meant for display.
+func normalizeURL(link string) string {
+	link = unwrapURL(strings.TrimSpace(link))
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" ||
+		(!strings.EqualFold(u.Scheme, "http") && !strings.EqualFold(u.Scheme, "https")) {
+		return link
+	}
+
+	// Tweets
+	if isTwitterHost(u.Hostname()) {
+		if match := urlTweetPath.FindStringSubmatch(u.Path); match != nil {
+			return canonicalTweetURL(match[1])
+		}
+	}
+
+	u.Scheme = "https"
+	u.Host = strings.ToLower(u.Host)
+	u.Host = strings.TrimSuffix(strings.TrimSuffix(u.Host, ":443"), ":80")
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+	if u.Path == "/" {
+		u.Path = ""
+		u.RawPath = ""
+	}
+
+	// Strip tracking params
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			lowerKey := strings.ToLower(key)
+			if strings.HasPrefix(lowerKey, "utm_") {
+				query.Del(key)
+				continue
+			}
+			for _, param := range urlTrackingParams {
+				if lowerKey == param {
+					query.Del(key)
+					break
+				}
+			}
+		}
+		u.RawQuery = query.Encode() // also sorts remaining params
+	}
+
+	return u.String()
+}
+
+//#endregion
+
+//#region Text
+
+var (
+	markdownEscaper    = strings.NewReplacer("*", "\\*", "_", "\\_", "~", "\\~", "`", "\\`", "|", "\\|")
+	markdownBlankLines = regexp.MustCompile(`\n{3,}`)
+	markdownSpaces     = regexp.MustCompile(`[ \t\r\n]+`)
+)
+
+// Converts simple HTML (descriptions, show notes) to Discord markdown. Unknown tags are dropped, their text kept.
+func htmlToMarkdown(input string) string {
+	var out strings.Builder
+	var links []string // hrefs of open <a> tags
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			result := markdownBlankLines.ReplaceAllString(out.String(), "\n\n")
+			return strings.TrimSpace(result)
+		case html.TextToken:
+			out.WriteString(markdownEscaper.Replace(markdownSpaces.ReplaceAllString(string(tokenizer.Text()), " ")))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			switch string(name) {
+			case "b", "strong", "h1", "h2", "h3", "h4", "h5", "h6":
+				out.WriteString("**")
+			case "i", "em":
+				out.WriteString("_")
+			case "br":
+				out.WriteString("\n")
+			case "li":
+				out.WriteString("\n- ")
+			case "a":
+				href := ""
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = tokenizer.TagAttr()
+					if string(key) == "href" {
+						href = string(val)
+					}
+				}
+				links = append(links, href)
+				if href != "" {
+					out.WriteString("[")
+				}
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "b", "strong":
+				out.WriteString("**")
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				out.WriteString("**\n")
+			case "i", "em":
+				out.WriteString("_")
+			case "p", "div", "ul", "ol":
+				out.WriteString("\n\n")
+			case "a":
+				if len(links) > 0 {
+					href := links[len(links)-1]
+					links = links[:len(links)-1]
+					if href != "" {
+						out.WriteString("](" + href + ")")
+					}
+				}
+			}
+		}
+	}
+}
+
+// Cuts text to a length in characters, ending with an ellipsis if anything was cut.
+func truncateText(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:length-1])) + "…"
+}
+
 //#endregion
@@ -95,6 +95,9 @@ func loadConfig_General() error {
 			if err = json.Unmarshal([]byte(configStr), &generalConfig); err != nil {
 				return fmt.Errorf("failed to parse general config file: %s", err)
 			}
+			if err = checkProxySettings(generalConfig.Proxy, generalConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid general proxy settings: %s", err)
+			}
 			// Output?
 			if generalConfig.OutputSettings {
 				s, err := json.MarshalIndent(generalConfig, "", "\t")
@@ -120,6 +123,20 @@ type configGeneralSettings struct {
 	Debug2         bool   `json:"debug2"` // verbose debug
 	OutputSettings bool   `json:"outputSettings"`
 	DefaultColor   string `json:"defaultColor,omitempty"`
+
+	FeedJitterSecs     int   `json:"feedJitterSecs,omitempty"`     // up to X random seconds added to each feed's wait
+	FeedStartupStagger *bool `json:"feedStartupStagger,omitempty"` // spread initial runs over each feed's first interval, default true
+
+	FeedPauseAfterFailures int `json:"feedPauseAfterFailures,omitempty"` // pause a feed after X failures in a row, default 10, -1 to never
+	FeedBackoffMaxMins     int `json:"feedBackoffMaxMins,omitempty"`     // longest wait when backing off from failures, default 1440
+
+	FeedRunTimeoutMins  int `json:"feedRunTimeoutMins,omitempty"`  // X minutes before a single feed run is abandoned, default 15
+	ShutdownTimeoutSecs int `json:"shutdownTimeoutSecs,omitempty"` // X seconds to wait for running feeds when exiting, default 30
+
+	SessionCheckMins int `json:"sessionCheckMins,omitempty"` // X minutes between scraper login checks, default 30, -1 to never
+
+	Proxy     string   `json:"proxy,omitempty"`     // used for all outbound requests unless a module or feed sets its own, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through by the scrapers instead of proxy
 }
 
 //#endregion
@@ -146,8 +163,29 @@ func loadConfig_Modules_Credentials() error {
 			spotifyClientID = config.Section("").Key("spotify_client_id").String()
 			spotifyClientSecret = config.Section("").Key("spotify_client_secret").String()
 
+			nasaAPIKey = config.Section("").Key("nasa_api_key").String()
+
+			plexToken = config.Section("").Key("plex_token").String()
+
+			twitchClientID = config.Section("").Key("twitch_client_id").String()
+			twitchClientSecret = config.Section("").Key("twitch_client_secret").String()
+
 			twitterUsername = config.Section("").Key("twitter_username").String()
 			twitterPassword = config.Section("").Key("twitter_password").String()
+			twitterLogins = nil
+			if twitterUsername != "" && twitterPassword != "" {
+				twitterLogins = append(twitterLogins, twitterLogin{twitterUsername, twitterPassword})
+			}
+			// Extra accounts to rotate through, as [twitter.NAME] sections with username & password keys
+			for _, section := range config.Sections() {
+				if strings.HasPrefix(strings.ToLower(section.Name()), "twitter.") {
+					username := section.Key("username").String()
+					password := section.Key("password").String()
+					if username != "" && password != "" {
+						twitterLogins = append(twitterLogins, twitterLogin{username, password})
+					}
+				}
+			}
 		}
 	} else {
 		return fmt.Errorf("module credentials file not found: %s", err)
@@ -157,10 +195,15 @@ func loadConfig_Modules_Credentials() error {
 
 func loadConfig_Modules() map[string]error {
 	return map[string]error{
+		"mod-flickr":    loadConfig_Module_Flickr(),
 		"mod-instagram": loadConfig_Module_Instagram(),
 		"mod-rss":       loadConfig_Module_RSS(),
 		"mod-twitter":   loadConfig_Module_Twitter(),
 		"mod-spotify":   loadConfig_Module_Spotify(),
+		"mod-twitch":    loadConfig_Module_Twitch(),
+		"mod-plex":      loadConfig_Module_Plex(),
+		"mod-apod":      loadConfig_Module_APOD(),
+		"mod-sysmon":    loadConfig_Module_Sysmon(),
 	}
 }
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -17,12 +18,81 @@ var (
 	dbRefs *gorm.DB
 )
 
+type dbMigration struct {
+	gorm.Model
+	Name string `gorm:"uniqueIndex"`
+}
+
 type dbRef struct {
 	gorm.Model
 	Ref       string // url, link, etc
 	Channel   string // discord channel it's sent to
 	Module    string
 	Timestamp time.Time
+
+	URL         string `gorm:"index"` // normalized link, for matching the same story across feeds
+	TitleHash   int64  // simhash of the title, for near-duplicate titles
+	Source      string // name of the feed it came from
+	MessageID   string // discord message it was sent as
+	DuplicateOf uint   // if suppressed as a duplicate, the ref that was sent instead
+}
+
+// HTTP validators from the last successful fetch of a feed, for conditional requests.
+type dbFeedCache struct {
+	gorm.Model
+	Feed         string `gorm:"uniqueIndex"` // feed url
+	ETag         string
+	LastModified string
+}
+
+// Runtime state of a feed, kept across restarts.
+type dbFeedState struct {
+	gorm.Model
+	Group       int    `gorm:"uniqueIndex:idx_feed_state_feed"`
+	Name        string `gorm:"uniqueIndex:idx_feed_state_feed"`
+	LastRan     time.Time
+	LastSuccess time.Time
+	LastNewItem time.Time
+	NextRun     time.Time
+	TimesRan    int
+	TimesFailed int
+	Failures    int // consecutive
+	LastError   string
+	LastErrorAt time.Time
+	Paused      bool
+	Cursor      string // module specific position data
+}
+
+func (dbFeedState) TableName() string {
+	return "feed_state"
+}
+
+// Last seen contents of a Spotify playlist, diffed against the next snapshot.
+type dbPlaylistTrack struct {
+	gorm.Model
+	Feed     string `gorm:"index"` // feed name, feeds on the same playlist keep their own snapshot
+	Playlist string `gorm:"index"` // playlist ID
+	Key      string // track URI and when it was added, the same track can be on a playlist twice
+	URI      string
+	Name     string
+	Artists  string
+	Album    string
+	Image    string
+	Link     string
+	AddedBy  string // user ID
+	AddedAt  time.Time
+}
+
+func (dbPlaylistTrack) TableName() string {
+	return "playlist_track"
+}
+
+// Details of an item to be logged, only Ref is required.
+type refItem struct {
+	Ref    string
+	URL    string
+	Title  string
+	Source string
 }
 
 func loadDatabase() error {
@@ -36,15 +106,92 @@ func loadDatabase() error {
 	if err != nil {
 		return err
 	}
-	dbRefs.AutoMigrate(&dbRef{})
+	// Feeds save state from their own routines, sqlite only handles one writer at a time
+	if sqlDB, err := dbRefs.DB(); err == nil {
+		sqlDB.SetMaxOpenConns(1)
+	}
+	dbRefs.AutoMigrate(&dbRef{}, &dbMigration{}, &dbFeedCache{}, &dbFeedState{}, &dbPlaylistTrack{})
+
+	return migrateDatabase()
+}
 
+//#region Migrations
+
+// Named one-time migrations, applied in order and recorded in the migrations table.
+var dbMigrations = []struct {
+	Name string
+	Run  func(*gorm.DB) error
+}{
+	{"normalize-refs", migrateNormalizeRefs},
+	{"playlist-snapshots-per-feed", migratePlaylistSnapshotsPerFeed},
+}
+
+func migrateDatabase() error {
+	for _, migration := range dbMigrations {
+		var count int64
+		dbRefs.Model(&dbMigration{}).Where("`name` = ?", migration.Name).Count(&count)
+		if count > 0 {
+			continue
+		}
+		// Recorded in the same transaction, so a migration that isn't recorded is rolled back and runs again
+		err := dbRefs.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Run(tx); err != nil {
+				return err
+			}
+			if err := tx.Create(&dbMigration{Name: migration.Name}).Error; err != nil {
+				return fmt.Errorf("error recording migration: %s", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("migration \"%s\" failed: %s", migration.Name, err)
+		}
+	}
 	return nil
 }
 
-func refCount() int {
+// Rewrites refs logged as raw links to their normalized form, see normalizeURL().
+func migrateNormalizeRefs(tx *gorm.DB) error {
 	var refs []dbRef
-	dbRefs.Model(&dbRef{}).Find(&refs)
-	return len(refs)
+	return tx.Model(&dbRef{}).FindInBatches(&refs, 500, func(batch *gorm.DB, _ int) error {
+		for _, ref := range refs {
+			if normalized := normalizeURL(ref.Ref); normalized != ref.Ref {
+				if err := tx.Model(&dbRef{}).Where("`id` = ?", ref.ID).Update("ref", normalized).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}).Error
+}
+
+// Playlist snapshots were shared by every feed on the playlist, gives each configured feed its own copy.
+// Needs the config loaded, which happens before the database.
+func migratePlaylistSnapshotsPerFeed(tx *gorm.DB) error {
+	for _, feed := range spotifyConfig.Playlists {
+		var tracks []dbPlaylistTrack
+		if err := tx.Where("`feed` = '' AND `playlist` = ?", parseSpotifyID(feed.ID, "playlist")).Find(&tracks).Error; err != nil {
+			return err
+		}
+		for k := range tracks {
+			tracks[k].ID = 0
+			tracks[k].Feed = feed.Name
+		}
+		if len(tracks) > 0 {
+			if err := tx.CreateInBatches(tracks, 200).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Unscoped().Where("`feed` = ''").Delete(&dbPlaylistTrack{}).Error
+}
+
+//#endregion
+
+func refCount() int {
+	var count int64
+	dbRefs.Model(&dbRef{}).Where("`duplicate_of` = 0").Count(&count)
+	return int(count)
 }
 
 func refCheckSentAnywhere(ref string) bool {
@@ -59,11 +206,190 @@ func refCheckSentToChannel(ref string, channel string) bool {
 	return len(refs) > 0
 }
 
+// Checks each candidate ref for an item (e.g. GUID and normalized link).
+func refCheckAnySentToChannel(refs []string, channel string) bool {
+	for _, ref := range refs {
+		if ref != "" && refCheckSentToChannel(ref, channel) {
+			return true
+		}
+	}
+	return false
+}
+
 func refLogSent(ref string, channel string, module string) {
+	refLogSentItem(refItem{Ref: ref}, channel, module, "")
+}
+
+func refLogSentItem(item refItem, channel string, module string, messageID string) {
 	dbRefs.Create(&dbRef{
-		Ref:       ref,
+		Ref:       item.Ref,
 		Channel:   channel,
 		Module:    module,
 		Timestamp: time.Now(),
+		URL:       item.URL,
+		TitleHash: int64(titleSimhash(item.Title)),
+		Source:    item.Source,
+		MessageID: messageID,
+	})
+}
+
+//#region Feed Cache
+
+func feedCacheGet(feed string) dbFeedCache {
+	var cache dbFeedCache
+	dbRefs.Model(&dbFeedCache{}).Where("`feed` = ?", feed).Limit(1).Find(&cache)
+	return cache
+}
+
+func feedCacheSet(feed string, etag string, lastModified string) {
+	cache := feedCacheGet(feed)
+	cache.Feed = feed
+	cache.ETag = etag
+	cache.LastModified = lastModified
+	dbRefs.Save(&cache)
+}
+
+//#endregion
+
+//#region Feed State
+
+func feedStateGet(group int, name string) dbFeedState {
+	var state dbFeedState
+	dbRefs.Model(&dbFeedState{}).Where("`group` = ? AND `name` = ?", group, name).Limit(1).Find(&state)
+	return state
+}
+
+func feedStateSave(state dbFeedState) {
+	existing := feedStateGet(state.Group, state.Name)
+	state.Model = existing.Model
+	state.Cursor = existing.Cursor // only changed through feedCursorSet()
+	dbRefs.Save(&state)
+}
+
+func feedStateDelete(group int, name string) {
+	dbRefs.Unscoped().Where("`group` = ? AND `name` = ?", group, name).Delete(&dbFeedState{})
+}
+
+func feedCursorGet(group int, name string) string {
+	return feedStateGet(group, name).Cursor
+}
+
+func feedCursorSet(group int, name string, cursor string) {
+	state := feedStateGet(group, name)
+	if state.ID == 0 {
+		state.Group = group
+		state.Name = name
+	}
+	state.Cursor = cursor
+	dbRefs.Save(&state)
+}
+
+//#endregion
+
+//#region Playlists
+
+func playlistTracksGet(feed string, playlist string) []dbPlaylistTrack {
+	var tracks []dbPlaylistTrack
+	dbRefs.Model(&dbPlaylistTrack{}).Where("`feed` = ? AND `playlist` = ?", feed, playlist).Find(&tracks)
+	return tracks
+}
+
+// Replaces a feed's stored contents of a playlist.
+func playlistTracksSet(feed string, playlist string, tracks []dbPlaylistTrack) error {
+	return dbRefs.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("`feed` = ? AND `playlist` = ?", feed, playlist).Delete(&dbPlaylistTrack{}).Error; err != nil {
+			return err
+		}
+		if len(tracks) == 0 {
+			return nil
+		}
+		rows := make([]dbPlaylistTrack, len(tracks))
+		for k, track := range tracks {
+			track.ID = 0 // rows from the last snapshot are written again as new
+			track.Feed = feed
+			rows[k] = track
+		}
+		return tx.CreateInBatches(rows, 200).Error
 	})
 }
+
+//#endregion
+
+//#region Duplicates
+
+// Finds an item another feed already sent to the channel within the channel's dedup window that is the same story
+// as this one, either by normalized URL or (if enabled) by a near-duplicate title. Returns nil if not a duplicate
+// or dedup is off. Items from the same feed are never duplicates of each other, that's what the ref check is for.
+func refFindDuplicate(item refItem, channel string) *dbRef {
+	settings := getDiscordChannelSettings(channel)
+	if settings == nil || settings.DedupWindowMins <= 0 {
+		return nil
+	}
+	since := time.Now().Add(-time.Duration(settings.DedupWindowMins) * time.Minute)
+	window := func() *gorm.DB {
+		query := dbRefs.Model(&dbRef{}).Where("`channel` = ? AND `timestamp` > ? AND `duplicate_of` = 0", channel, since)
+		if item.Source != "" {
+			query = query.Where("`source` <> ?", item.Source)
+		}
+		return query.Order("`timestamp` asc")
+	}
+
+	if item.URL != "" {
+		var refs []dbRef
+		window().Where("`url` = ?", item.URL).Limit(1).Find(&refs)
+		if len(refs) > 0 {
+			return &refs[0]
+		}
+	}
+
+	titleHash := titleSimhash(item.Title)
+	if !settings.DedupTitles || titleHash == 0 {
+		return nil
+	}
+	var refs []dbRef
+	window().Where("`title_hash` <> 0").Find(&refs)
+	for k, ref := range refs {
+		if hammingDistance(titleHash, uint64(ref.TitleHash)) <= settings.getDedupTitleDistance() {
+			return &refs[k]
+		}
+	}
+	return nil
+}
+
+// Logs an item as seen but suppressed in favour of the original, so it is not considered again.
+func refLogDuplicate(item refItem, channel string, module string, original dbRef) {
+	dbRefs.Create(&dbRef{
+		Ref:         item.Ref,
+		Channel:     channel,
+		Module:      module,
+		Timestamp:   time.Now(),
+		URL:         item.URL,
+		Source:      item.Source,
+		DuplicateOf: original.ID,
+	})
+}
+
+// Names of the other feeds that reported the same item as the original.
+func refDuplicateSources(original dbRef) []string {
+	var refs []dbRef
+	dbRefs.Model(&dbRef{}).Where("`duplicate_of` = ?", original.ID).Order("`timestamp` asc").Find(&refs)
+	var sources []string
+	for _, ref := range refs {
+		if ref.Source == "" || ref.Source == original.Source {
+			continue
+		}
+		exists := false
+		for _, source := range sources {
+			if source == ref.Source {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			sources = append(sources, ref.Source)
+		}
+	}
+	return sources
+}
+
+//#endregion
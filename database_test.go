@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestPlaylistTracksPerFeed(t *testing.T) {
+	setupTestDatabase(t)
+	tracks := []dbPlaylistTrack{{Playlist: "p1", Key: "a"}, {Playlist: "p1", Key: "b"}}
+	if err := playlistTracksSet("First", "p1", tracks); err != nil {
+		t.Fatal(err)
+	}
+	if err := playlistTracksSet("Second", "p1", tracks[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(playlistTracksGet("First", "p1")); got != 2 {
+		t.Errorf("first feed has %d tracks, want 2", got)
+	}
+	if got := len(playlistTracksGet("Second", "p1")); got != 1 {
+		t.Errorf("second feed has %d tracks, want 1", got)
+	}
+
+	// Saving the rows read back replaces them rather than colliding on their IDs
+	if err := playlistTracksSet("First", "p1", playlistTracksGet("First", "p1")); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(playlistTracksGet("First", "p1")); got != 2 {
+		t.Errorf("first feed has %d tracks after saving again, want 2", got)
+	}
+}
+
+func TestMigratePlaylistSnapshotsPerFeed(t *testing.T) {
+	setupTestDatabase(t)
+	previous := spotifyConfig.Playlists
+	spotifyConfig.Playlists = []configModuleSpotifyPlaylist{
+		{Name: "First", ID: "https://open.spotify.com/playlist/p1"},
+		{Name: "Second", ID: "p1"},
+	}
+	t.Cleanup(func() { spotifyConfig.Playlists = previous })
+
+	dbRefs.Create(&[]dbPlaylistTrack{{Playlist: "p1", Key: "a"}, {Playlist: "p1", Key: "b"}, {Playlist: "gone", Key: "c"}})
+	if err := dbRefs.Transaction(migratePlaylistSnapshotsPerFeed); err != nil {
+		t.Fatal(err)
+	}
+	for _, feed := range []string{"First", "Second"} {
+		if got := len(playlistTracksGet(feed, "p1")); got != 2 {
+			t.Errorf("%s has %d tracks, want 2", feed, got)
+		}
+	}
+	var legacy int64
+	dbRefs.Model(&dbPlaylistTrack{}).Where("`feed` = ''").Count(&legacy)
+	if legacy != 0 {
+		t.Errorf("%d shared rows left", legacy)
+	}
+}
@@ -21,6 +21,8 @@ var (
 	discordConfigDef_Presence_Enabled bool = true
 )
 
+const discordMessageLimit = 2000 // characters
+
 type configDiscordPresence struct {
 	Enabled       *bool                  `json:"enabled"`       // really just to optionally disable
 	Type          string                 `json:"type"`          // Online, Idle, DND, Invisible
@@ -36,8 +38,36 @@ type configDiscordSettings struct {
 	//ExitOnBadConnection bool     `json:"exitOnBadConnection,omitempty"`
 	//OutputMessages      bool     `json:"outputMessages,omitempty"`
 	Admins         []string                `json:"admins"`
+	AdminChannel   string                  `json:"adminChannel,omitempty"` // for alerts, DMs admins if empty
 	DeleteCommands bool                    `json:"deleteCommands"`
 	Presence       []configDiscordPresence `json:"presence"`
+	Channels       []configDiscordChannel  `json:"channels,omitempty"`
+}
+
+// Settings for a destination channel, shared by every feed sending to it.
+type configDiscordChannel struct {
+	Channel string `json:"channel"`
+	// Duplicate suppression, off unless a window is set
+	DedupWindowMins    int  `json:"dedupWindowMins,omitempty"`    // X minutes to look back for the same item from any feed
+	DedupTitles        bool `json:"dedupTitles,omitempty"`        // also match near-duplicate titles, not just URLs
+	DedupTitleDistance int  `json:"dedupTitleDistance,omitempty"` // max differing bits of title simhash, default 3
+	AlsoReportedBy     bool `json:"alsoReportedBy,omitempty"`     // list suppressed sources on the original post
+}
+
+func (c configDiscordChannel) getDedupTitleDistance() int {
+	if c.DedupTitleDistance > 0 {
+		return c.DedupTitleDistance
+	}
+	return 3
+}
+
+func getDiscordChannelSettings(channel string) *configDiscordChannel {
+	for k, settings := range discordConfig.Channels {
+		if settings.Channel == channel {
+			return &discordConfig.Channels[k]
+		}
+	}
+	return nil
 }
 
 var discordConfigDefault = configDiscordSettings{
@@ -159,6 +189,13 @@ func openDiscord() error {
 	if err != nil {
 		return fmt.Errorf("error creating discord client: %s", err.Error())
 	}
+	if proxy := getDiscordProxy(); proxy != "" {
+		transport, err := getProxyTransport(proxy)
+		if err != nil {
+			return fmt.Errorf("error setting discord proxy: %s", err.Error())
+		}
+		discord.Client.Transport = transport
+	}
 
 	err = discord.Open()
 	if err != nil {
@@ -249,6 +286,37 @@ func isBotAdmin(id string) bool {
 	return false
 }
 
+// Alerts the admin channel, or each admin directly if there isn't one.
+func notifyAdmins(message string) {
+	l := logInstructions{
+		Location: "notifyAdmins",
+		Task:     "",
+		Inline:   true,
+		Color:    color.YellowString,
+	}
+	log.Println(l.Log(message))
+	if discord == nil {
+		return
+	}
+	if discordConfig.AdminChannel != "" {
+		if _, err := discord.ChannelMessageSend(discordConfig.AdminChannel, message); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Failed to alert admin channel: %s", err))
+			l.ClearFlag()
+		}
+		return
+	}
+	for _, admin := range discordConfig.Admins {
+		channel, err := discord.UserChannelCreate(admin)
+		if err == nil {
+			_, err = discord.ChannelMessageSend(channel.ID, message)
+		}
+		if err != nil {
+			log.Println(l.SetFlag(&lError).Log("Failed to alert admin %s: %s", admin, err))
+			l.ClearFlag()
+		}
+	}
+}
+
 func getAuthor(i *discordgo.InteractionCreate) *discordgo.User {
 	if i.Member == nil && i.Message.Author == nil {
 		return nil
@@ -2,15 +2,39 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 )
 
 type feedDestination struct {
 	Channel string   `json:"channel"`
-	Tags    []string `json:"tags,omitempty"`
+	Tags    []string `json:"tags,omitempty"`  // user IDs to mention
+	Roles   []string `json:"roles,omitempty"` // role IDs to mention
+}
+
+// Mentions for the users and roles of a destination, "" if there are none.
+func getDestinationMentions(destination feedDestination) string {
+	var mentions []string
+	for _, tag := range destination.Tags {
+		mentions = append(mentions, fmt.Sprintf("<@%s>", tag))
+	}
+	for _, role := range destination.Roles {
+		mentions = append(mentions, fmt.Sprintf("<@&%s>", role))
+	}
+	return strings.Join(mentions, ", ")
 }
 
 type feedThread struct {
@@ -22,26 +46,75 @@ type feedThread struct {
 	LastRan  time.Time
 	TimesRan int
 	Running  bool
+	NextRun  time.Time
+
+	// Adaptive Scheduling
+	Adaptive    bool
+	MinWaitMins int
+	MaxWaitMins int
+	// Schedule Expression, overrides the above
+	Schedule      feedSchedule
+	ScheduleLabel string
+
+	LastSuccess time.Time
+	LastNewItem time.Time
+
+	// Health
+	Failures    int // consecutive
+	TimesFailed int
+	LastError   string
+	LastErrorAt time.Time
+	Paused      bool
+
+	Deleted     bool
+	Generation  int           // bumped when the watchdog replaces a stuck routine, old routines exit
+	RunNow      bool          // run once, out of schedule (and even if paused)
+	Wake        chan struct{} // interrupts the wait between runs
+	Result      chan error    // handler result for the current run, also identifies the run
+	HandlerDone chan struct{} // closed once the run's handler returns, even if it was abandoned
 }
 
-var feeds []feedThread
+var (
+	feeds        []*feedThread
+	feedsMutex   sync.Mutex     // guards feeds and the fields of every feedThread in it
+	feedsRunning sync.WaitGroup // runs in progress, waited on at shutdown
+)
+
+// Copies of every live feed, for reading without holding the lock.
+func getFeedsSnapshot() []feedThread {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
+	snapshot := make([]feedThread, 0, len(feeds))
+	for _, feed := range feeds {
+		snapshot = append(snapshot, *feed)
+	}
+	return snapshot
+}
 
 const (
 	feed0 = iota
 	feed1
 
 	feedInstagramAccount
+	feedRSS
+	feedTwitterAccount
 
-	//feedFlickrGroup
-	//feedFlickrUser
+	// New groups go at the end, the numbers are saved with each feed's state.
 
-	feedRSS
+	feedSpotifyArtist
+	feedSpotifyPlaylist
+	feedSpotifyPodcast
 
-	//feedSpotifyArtist
-	//feedSpotifyPlaylist
-	//feedSpotifyPodcast
+	feedFlickrGroup
+	feedFlickrUser
 
-	feedTwitterAccount
+	feedTwitchLive
+
+	feedPlexServer
+
+	feedAPOD
+
+	feedTwitterTrends
 )
 
 func getFeedTypeName(moduleType int) string {
@@ -52,25 +125,35 @@ func getFeedTypeName(moduleType int) string {
 		return "PLACEHOLDER"
 	case feedInstagramAccount:
 		return "Instagram Account"
-	//case feedFlickrGroup:
-	//	return "Flickr Group"
-	//case feedFlickrUser:
-	//	return "Flickr User"
+	case feedAPOD:
+		return "NASA APOD"
+	case feedFlickrGroup:
+		return "Flickr Group"
+	case feedFlickrUser:
+		return "Flickr User"
+	case feedPlexServer:
+		return "Plex Server"
 	case feedRSS:
 		return "RSS Feed"
-	//case feedSpotifyArtist:
-	//	return "Spotify Artist"
-	//case feedSpotifyPlaylist:
-	//	return "Spotify Playlist"
-	//case feedSpotifyPodcast:
-	//	return "Spotify Podcast"
+	case feedSpotifyArtist:
+		return "Spotify Artist"
+	case feedSpotifyPlaylist:
+		return "Spotify Playlist"
+	case feedSpotifyPodcast:
+		return "Spotify Podcast"
 	case feedTwitterAccount:
 		return "Twitter Account"
+	case feedTwitterTrends:
+		return "Twitter Trends"
+	case feedTwitchLive:
+		return "Twitch Live"
 	}
 	return ""
 }
 
 func getFeedCount(filterGroup int) int {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
 	if filterGroup != feed0 {
 		counter := 0
 		for _, feed := range feeds {
@@ -84,6 +167,8 @@ func getFeedCount(filterGroup int) int {
 }
 
 func getFeedsRunningCount(filterGroup int) int {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
 	counter := 0
 	for _, feed := range feeds {
 		if feed.Running && feed.Group == filterGroup {
@@ -94,6 +179,8 @@ func getFeedsRunningCount(filterGroup int) int {
 }
 
 func getFeedsSleepingCount(filterGroup int) int {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
 	counter := 0
 	for _, feed := range feeds {
 		if !feed.Running && feed.Group == filterGroup {
@@ -106,8 +193,8 @@ func getFeedsSleepingCount(filterGroup int) int {
 func getFeedsLatest() *feedThread {
 	var latestFeed *feedThread = nil
 	for _, feed := range feeds {
-		if feed.LastRan.After(latestFeed.LastRan) {
-			latestFeed = &feed
+		if latestFeed == nil || feed.LastRan.After(latestFeed.LastRan) {
+			latestFeed = feed
 		}
 	}
 	return latestFeed
@@ -117,105 +204,446 @@ func catalogFeeds() {
 	//feeds = make([]moduleFeed, 0)
 	// RSS Feeds
 	for _, feed := range rssConfig.Feeds {
-		waitMins := rssConfig.WaitMins
-		if feed.WaitMins != nil {
-			waitMins = *feed.WaitMins
-		}
-
-		feeds = append(feeds, feedThread{
-			Group:    feedRSS,
-			Name:     feed.Name,
-			Ref:      "\"" + feed.URL + "\"",
-			Config:   feed,
-			WaitMins: waitMins,
-		})
+		thread := newRssFeedThread(feed)
+		feeds = append(feeds, &thread)
+	}
+	// Flickr, Users & Groups
+	for _, user := range flickrConfig.Users {
+		thread := newFlickrFeedThread(user, feedFlickrUser)
+		feeds = append(feeds, &thread)
+	}
+	for _, group := range flickrConfig.Groups {
+		thread := newFlickrFeedThread(group, feedFlickrGroup)
+		feeds = append(feeds, &thread)
 	}
 	// Instagram, Accounts
 	for _, account := range instagramConfig.Accounts {
-		waitMins := instagramConfig.WaitMins
-		if account.WaitMins != nil {
-			waitMins = *account.WaitMins
-		}
-
-		feeds = append(feeds, feedThread{
-			Group:    feedInstagramAccount,
-			Name:     account.Name,
-			Ref:      account.ID,
-			Config:   account,
-			WaitMins: waitMins,
-		})
+		thread := newInstagramAccFeedThread(account)
+		feeds = append(feeds, &thread)
+	}
+	// NASA, APOD
+	for _, feed := range apodConfig.Feeds {
+		thread := newAPODFeedThread(feed)
+		feeds = append(feeds, &thread)
+	}
+	// Plex, Servers
+	for _, server := range plexConfig.Servers {
+		thread := newPlexServerFeedThread(server)
+		feeds = append(feeds, &thread)
+	}
+	// Spotify, Artists
+	for _, artist := range spotifyConfig.Artists {
+		thread := newSpotifyArtistFeedThread(artist)
+		feeds = append(feeds, &thread)
+	}
+	// Spotify, Playlists
+	for _, playlist := range spotifyConfig.Playlists {
+		thread := newSpotifyPlaylistFeedThread(playlist)
+		feeds = append(feeds, &thread)
+	}
+	// Spotify, Podcasts
+	for _, podcast := range spotifyConfig.Podcasts {
+		thread := newSpotifyPodcastFeedThread(podcast)
+		feeds = append(feeds, &thread)
+	}
+	// Twitch, Live
+	for _, channel := range twitchConfig.Channels {
+		thread := newTwitchLiveFeedThread(channel)
+		feeds = append(feeds, &thread)
 	}
 	// Twitter, Accounts
 	for _, account := range twitterConfig.Accounts {
-		waitMins := twitterConfig.WaitMins
-		if account.WaitMins != nil {
-			waitMins = *account.WaitMins
+		thread := newTwitterAccFeedThread(account)
+		feeds = append(feeds, &thread)
+	}
+	// Twitter, Trends
+	for _, trends := range twitterConfig.Trends {
+		thread := newTwitterTrendsFeedThread(trends)
+		feeds = append(feeds, &thread)
+	}
+	// Restore
+	for _, feed := range feeds {
+		loadFeedState(feed)
+	}
+}
+
+// Adds a new feed to the live feeds and starts it.
+func spawnFeed(feed feedThread) {
+	loadFeedState(&feed)
+	feedsMutex.Lock()
+	feeds = append(feeds, &feed)
+	feedsMutex.Unlock()
+	go startFeed(&feed)
+}
+
+// Waits between runs, returning early if woken for a run now, resume, etc.
+func sleepFeed(feed *feedThread, wait time.Duration) {
+	feedsMutex.Lock()
+	wake := feed.Wake
+	feedsMutex.Unlock()
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-wake:
+	case <-ctxSchedule.Done():
+	}
+}
+
+// Waits for the feed's next run, which can be moved while waiting, returning early to run now or (un)pause.
+func sleepFeedUntilNext(feed *feedThread) {
+	feedsMutex.Lock()
+	paused := feed.Paused
+	feedsMutex.Unlock()
+	for ctxSchedule.Err() == nil {
+		feedsMutex.Lock()
+		wait := time.Until(feed.NextRun)
+		interrupted := feed.RunNow || feed.Deleted || feed.Paused != paused
+		feedsMutex.Unlock()
+		if interrupted || wait <= 0 {
+			return
+		}
+		sleepFeed(feed, wait)
+	}
+}
+
+func wakeFeed(feed *feedThread) {
+	feedsMutex.Lock()
+	wake := feed.Wake
+	feedsMutex.Unlock()
+	select {
+	case wake <- struct{}{}:
+	default: // already woken, or not started yet
+	}
+}
+
+// Runs the feed once out of schedule, false if it's already running.
+func runFeedNow(feed *feedThread) bool {
+	feedsMutex.Lock()
+	if feed.Running {
+		feedsMutex.Unlock()
+		return false
+	}
+	feed.RunNow = true
+	feedsMutex.Unlock()
+	wakeFeed(feed)
+	return true
+}
+
+// Feeds by name (case-insensitive), in any group if group is -1.
+func findFeeds(name string, group int) []*feedThread {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
+	var matches []*feedThread
+	for _, feed := range feeds {
+		if strings.EqualFold(feed.Name, name) && (group == -1 || feed.Group == group) {
+			matches = append(matches, feed)
 		}
+	}
+	return matches
+}
+
+// Pauses or resumes a feed, saving the choice to its config. Resuming also clears a pause from failures.
+func setFeedEnabled(feed *feedThread, enabled bool) error {
+	var err error
+	switch feed.Group {
+	case feedFlickrGroup, feedFlickrUser:
+		err = setFlickrConfigEnabled(feed.Name, feed.Group, enabled)
+	case feedInstagramAccount:
+		err = setInstagramAccConfigEnabled(feed.Name, enabled)
+	case feedAPOD:
+		err = setAPODConfigEnabled(feed.Name, enabled)
+	case feedPlexServer:
+		err = setPlexServerConfigEnabled(feed.Name, enabled)
+	case feedRSS:
+		err = setRssConfigEnabled(feed.Name, enabled)
+	case feedSpotifyArtist:
+		err = setSpotifyArtistConfigEnabled(feed.Name, enabled)
+	case feedSpotifyPlaylist:
+		err = setSpotifyPlaylistConfigEnabled(feed.Name, enabled)
+	case feedSpotifyPodcast:
+		err = setSpotifyPodcastConfigEnabled(feed.Name, enabled)
+	case feedTwitchLive:
+		err = setTwitchLiveConfigEnabled(feed.Name, enabled)
+	case feedTwitterAccount:
+		err = setTwitterAccConfigEnabled(feed.Name, enabled)
+	case feedTwitterTrends:
+		err = setTwitterTrendsConfigEnabled(feed.Name, enabled)
+	}
+	if err != nil {
+		return err
+	}
+	if err = saveModuleConfig(feed.Group); err != nil {
+		return fmt.Errorf("error saving config: %s", err.Error())
+	}
+
+	feedsMutex.Lock()
+	feed.Paused = !enabled
+	if enabled {
+		feed.Failures = 0
+	}
+	state := *feed
+	feedsMutex.Unlock()
+	saveFeedState(&state)
+	wakeFeed(feed)
+	return nil
+}
+
+func loadFeedState(feed *feedThread) {
+	state := feedStateGet(feed.Group, feed.Name)
+	if state.ID == 0 {
+		return
+	}
+	feed.LastRan = state.LastRan
+	feed.LastSuccess = state.LastSuccess
+	feed.LastNewItem = state.LastNewItem
+	feed.NextRun = state.NextRun
+	feed.TimesRan = state.TimesRan
+	feed.TimesFailed = state.TimesFailed
+	feed.Failures = state.Failures
+	feed.LastError = state.LastError
+	feed.LastErrorAt = state.LastErrorAt
+	feed.Paused = feed.Paused || state.Paused // either disabled in config or paused from failures
+}
+
+func saveFeedState(feed *feedThread) {
+	feedStateSave(dbFeedState{
+		Group:       feed.Group,
+		Name:        feed.Name,
+		LastRan:     feed.LastRan,
+		LastSuccess: feed.LastSuccess,
+		LastNewItem: feed.LastNewItem,
+		NextRun:     feed.NextRun,
+		TimesRan:    feed.TimesRan,
+		TimesFailed: feed.TimesFailed,
+		Failures:    feed.Failures,
+		LastError:   feed.LastError,
+		LastErrorAt: feed.LastErrorAt,
+		Paused:      feed.Paused,
+	})
+}
 
-		feeds = append(feeds, feedThread{
-			Group:    feedTwitterAccount,
-			Name:     account.Name,
-			Ref:      account.Handle,
-			Config:   account,
-			WaitMins: waitMins,
-		})
+// Called by handlers when something new was sent for the feed.
+func markFeedNewItem(group int, name string) {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
+	if feed := findModuleFeed(name, group); feed != nil {
+		feed.LastNewItem = time.Now()
 	}
 }
 
 func startFeed(feed *feedThread) {
+	feedsMutex.Lock()
+	if feed.Wake == nil {
+		feed.Wake = make(chan struct{}, 1)
+	}
+	generation := feed.Generation
+	if wait := getFeedStartWait(feed); wait > 0 {
+		feed.NextRun = time.Now().Add(wait)
+	}
+	feedsMutex.Unlock()
+	sleepFeedUntilNext(feed)
 	for {
-		if feed == nil { // deleted
-			break
+		// Never overlap runs, a handler abandoned after timing out may still be going
+		feedsMutex.Lock()
+		handlerDone := feed.HandlerDone
+		feedsMutex.Unlock()
+		if handlerDone != nil {
+			select {
+			case <-handlerDone:
+			case <-ctxSchedule.Done():
+				return
+			}
 		}
-		if feed.Name == "" || feed.Ref == "" { // deleted
-			break
+
+		feedsMutex.Lock()
+		if feed.Deleted || feed.Generation != generation || // deleted, or replaced by the watchdog
+			feed.Name == "" || feed.Ref == "" || ctxSchedule.Err() != nil { // shutting down
+			feedsMutex.Unlock()
+			return
 		}
-		feed.TimesRan++
-		feed.LastRan = time.Now()
-		feed.Running = true
-		switch feed.Group {
-		case feedInstagramAccount:
-			{
-				instagramAccount_Channel <- *feed
-			}
-		case feedRSS:
-			{
-				rssFeed_Channel <- *feed
-			}
-		case feedTwitterAccount:
-			{
-				twitterAccount_Channel <- *feed
-			}
+		if feed.Paused && !feed.RunNow {
+			feed.NextRun = time.Time{}
+			feedsMutex.Unlock()
+			sleepFeed(feed, time.Hour)
+			continue
 		}
+		feed.RunNow = false
+		result := make(chan error, 1)
+		feed.Result = result
+		feed.HandlerDone = make(chan struct{})
+		thread := *feed
+		feedsMutex.Unlock()
+
+		// Waits here while the group's dispatcher is busy, the run is only marked running once its handler starts
+		feedsRunning.Add(1)
+		select {
+		case getFeedChannel(thread.Group) <- thread:
+		case <-ctxSchedule.Done(): // shutdown before the dispatcher got to it
+			feedsRunning.Done()
+			return
+		}
+		err := <-result
+
+		feedsMutex.Lock()
+		if feed.Deleted || feed.Generation != generation || feed.Result != result { // deleted, or replaced while running and the new routine owns the feed now
+			feedsMutex.Unlock()
+			feedsRunning.Done()
+			return
+		}
+		alert := setFeedResult(feed, err)
 		feed.Running = false
-		time.Sleep(time.Duration(feed.WaitMins * int(time.Minute)))
+		state := *feed
+		feedsMutex.Unlock()
+		if alert != "" {
+			notifyAdmins(alert)
+		}
+
+		wait := getFeedNextWait(&state)
+		feedsMutex.Lock()
+		feed.NextRun = time.Now().Add(wait)
+		state = *feed
+		feedsMutex.Unlock()
+		saveFeedState(&state)
+		feedsRunning.Done()
+		sleepFeedUntilNext(feed)
+	}
+}
+
+// Called by the group's dispatcher as it takes a run, false if the feed was deleted or replaced while it waited.
+func beginFeedRun(thread feedThread) bool {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
+	feed := findModuleFeed(thread.Name, thread.Group)
+	if feed == nil || feed.Deleted || feed.Result != thread.Result || feed.Generation != thread.Generation {
+		return false
+	}
+	feed.TimesRan++
+	feed.LastRan = time.Now()
+	feed.Running = true
+	return true
+}
+
+func getFeedChannel(group int) chan feedThread {
+	switch group {
+	case feedFlickrGroup:
+		return flickrGroup_Channel
+	case feedFlickrUser:
+		return flickrUser_Channel
+	case feedInstagramAccount:
+		return instagramAccount_Channel
+	case feedAPOD:
+		return apod_Channel
+	case feedPlexServer:
+		return plexServer_Channel
+	case feedRSS:
+		return rssFeed_Channel
+	case feedSpotifyArtist:
+		return spotifyArtist_Channel
+	case feedSpotifyPlaylist:
+		return spotifyPlaylist_Channel
+	case feedSpotifyPodcast:
+		return spotifyPodcast_Channel
+	case feedTwitchLive:
+		return twitchLive_Channel
+	case feedTwitterAccount:
+		return twitterAccount_Channel
+	case feedTwitterTrends:
+		return twitterTrends_Channel
 	}
+	return nil
 }
 
+// Copy of the feed's current state, nil if there's no such feed.
 func getModuleFeed(name string, group int) *feedThread {
-	for k, feed := range feeds {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
+	if feed := findModuleFeed(name, group); feed != nil {
+		snapshot := *feed
+		return &snapshot
+	}
+	return nil
+}
+
+// The live feed, callers must hold feedsMutex.
+func findModuleFeed(name string, group int) *feedThread {
+	for _, feed := range feeds {
 		if feed.Name == name && feed.Group == group {
-			return &feeds[k]
+			return feed
 		}
 	}
 	return nil
 }
 
+// Swaps the live feed's config, picking up changes to its wait or schedule from the next run on.
 func updateFeedConfig(name string, group int, config interface{}) bool {
-	cloneFeeds := feeds
-	for i, feed := range cloneFeeds {
-		if feed.Name == name && feed.Group == group {
-			feeds[i].Config = config
-			return true
-		}
+	feedsMutex.Lock()
+	feed := findModuleFeed(name, group)
+	if feed == nil {
+		feedsMutex.Unlock()
+		return false
 	}
-	return false
+	feed.Config = config
+	rescheduled := false
+	if thread, ok := newFeedThreadFromConfig(group, config); ok {
+		rescheduled = thread.WaitMins != feed.WaitMins || thread.Adaptive != feed.Adaptive ||
+			thread.MinWaitMins != feed.MinWaitMins || thread.MaxWaitMins != feed.MaxWaitMins ||
+			thread.ScheduleLabel != feed.ScheduleLabel
+		feed.WaitMins = thread.WaitMins
+		feed.Adaptive = thread.Adaptive
+		feed.MinWaitMins = thread.MinWaitMins
+		feed.MaxWaitMins = thread.MaxWaitMins
+		feed.Schedule = thread.Schedule
+		feed.ScheduleLabel = thread.ScheduleLabel
+	}
+	// Waiting on the old schedule, running feeds pick the new one up once they finish
+	if rescheduled && !feed.Running && !feed.NextRun.IsZero() {
+		feed.NextRun = time.Now().Add(getFeedNextWait(feed))
+	}
+	feedsMutex.Unlock()
+	if rescheduled {
+		wakeFeed(feed)
+	}
+	return true
+}
+
+// Builds a thread for the config the way the module does at launch, for its scheduling.
+func newFeedThreadFromConfig(group int, config interface{}) (feedThread, bool) {
+	switch config := config.(type) {
+	case configModuleFlickrFeed:
+		return newFlickrFeedThread(config, group), true
+	case configModuleInstagramAcc:
+		return newInstagramAccFeedThread(config), true
+	case configModuleAPODFeed:
+		return newAPODFeedThread(config), true
+	case configModulePlexServer:
+		return newPlexServerFeedThread(config), true
+	case configModuleRssFeed:
+		return newRssFeedThread(config), true
+	case configModuleSpotifyArtist:
+		return newSpotifyArtistFeedThread(config), true
+	case configModuleSpotifyPlaylist:
+		return newSpotifyPlaylistFeedThread(config), true
+	case configModuleSpotifyPodcast:
+		return newSpotifyPodcastFeedThread(config), true
+	case configModuleTwitchChannel:
+		return newTwitchLiveFeedThread(config), true
+	case configModuleTwitterAcc:
+		return newTwitterAccFeedThread(config), true
+	case configModuleTwitterTrends:
+		return newTwitterTrendsFeedThread(config), true
+	}
+	return feedThread{}, false
 }
 
 func deleteFeed(name string, group int) bool {
+	feedsMutex.Lock()
+	defer feedsMutex.Unlock()
 	cloneFeeds := feeds
 	for i, feed := range cloneFeeds {
 		if feed.Name == name && feed.Group == group {
+			feed.Deleted = true // stops its routine
+			feedStateDelete(feed.Group, feed.Name)
 			feeds = append(feeds[:i], feeds[i+1:]...)
 			return true
 		}
@@ -223,13 +651,95 @@ func deleteFeed(name string, group int) bool {
 	return false
 }
 
+//#region Health
+
+const (
+	feedStateHealthy = "healthy"
+	feedStateFailing = "failing"
+	feedStatePaused  = "paused"
+)
+
+const feedErrorPreviewLength = 200 // characters of the last error shown in feed lists and alerts
+
+func getFeedState(feed feedThread) string {
+	if feed.Paused {
+		return feedStatePaused
+	} else if feed.Failures > 0 {
+		return feedStateFailing
+	}
+	return feedStateHealthy
+}
+
+// Records the outcome of a run, pausing the feed once it has failed too many times in a row.
+// Returns the alert for admins, if any, to be sent once the lock is released.
+func setFeedResult(feed *feedThread, err error) string {
+	alert := ""
+	if err == nil {
+		feed.LastSuccess = time.Now()
+		if feed.Failures >= 3 {
+			alert = fmt.Sprintf("✅ %s `%s` recovered after %d failure%s.",
+				getFeedTypeName(feed.Group), feed.Name, feed.Failures, ssuff(feed.Failures))
+		}
+		feed.Failures = 0
+		return alert
+	}
+	if ctxRoot.Err() != nil { // cut short by shutdown, not the feed's fault
+		return alert
+	}
+	feed.Failures++
+	feed.TimesFailed++
+	feed.LastError = err.Error()
+	feed.LastErrorAt = time.Now()
+
+	limit := generalConfig.FeedPauseAfterFailures
+	if limit == 0 {
+		limit = 10
+	}
+	if limit > 0 && feed.Failures >= limit {
+		feed.Paused = true
+		alert = fmt.Sprintf("⏸️ %s `%s` was paused after failing %d times in a row, use `/feed resume` once fixed.\n**Last error:** ```%s```",
+			getFeedTypeName(feed.Group), feed.Name, feed.Failures, truncateText(feed.LastError, feedErrorPreviewLength))
+	}
+	return alert
+}
+
+// Backed off wait after consecutive failures, doubling each time up to the limit.
+func getFeedBackoff(feed *feedThread, wait time.Duration) time.Duration {
+	if feed.Failures == 0 {
+		return wait
+	}
+	maxWait := 24 * time.Hour
+	if generalConfig.FeedBackoffMaxMins > 0 {
+		maxWait = time.Duration(generalConfig.FeedBackoffMaxMins) * time.Minute
+	}
+	for k := 1; k < feed.Failures && wait < maxWait; k++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	return wait
+}
+
+//#endregion
+
 func saveModuleConfig(feedType int) error {
 	switch feedType {
+	case feedFlickrGroup, feedFlickrUser:
+		return saveConfig(pathConfigModuleFlickr, flickrConfig)
 	case feedInstagramAccount:
 		return saveConfig(pathConfigModuleInstagram, instagramConfig)
+	case feedAPOD:
+		return saveConfig(pathConfigModuleAPOD, apodConfig)
+	case feedPlexServer:
+		return saveConfig(pathConfigModulePlex, plexConfig)
 	case feedRSS:
 		return saveConfig(pathConfigModuleRSS, rssConfig)
-	case feedTwitterAccount:
+	case feedSpotifyArtist, feedSpotifyPlaylist, feedSpotifyPodcast:
+		return saveConfig(pathConfigModuleSpotify, spotifyConfig)
+	case feedTwitchLive:
+		return saveConfig(pathConfigModuleTwitch, twitchConfig)
+	case feedTwitterAccount, feedTwitterTrends:
 		return saveConfig(pathConfigModuleTwitter, twitterConfig)
 	}
 	return nil
@@ -254,3 +764,318 @@ func saveModuleConfigReply(moduleGroup int, jsonFeed interface{}, reply string,
 		return replyConfig(jsonFeed, reply, s, i)
 	}
 }
+
+//#region Scheduling
+
+// Scheduling info reported by a feed handler, see setFeedHints().
+type feedScheduleHints struct {
+	MinInterval time.Duration  // shortest interval the source asks for (rss ttl, sy:updatePeriod)
+	SkipHours   []int          // UTC hours the source says not to poll
+	SkipDays    []time.Weekday // UTC days the source says not to poll
+	RetryAfter  time.Time      // don't poll before this (Retry-After)
+	ItemTimes   []time.Time    // post times of the items seen, for the feed's cadence
+}
+
+var (
+	feedHints      = make(map[string]feedScheduleHints)
+	feedHintsMutex sync.Mutex
+)
+
+func getFeedKey(group int, name string) string {
+	return fmt.Sprintf("%d:%s", group, strings.ToLower(name))
+}
+
+func setFeedHints(group int, name string, hints feedScheduleHints) {
+	feedHintsMutex.Lock()
+	defer feedHintsMutex.Unlock()
+	feedHints[getFeedKey(group, name)] = hints
+}
+
+func getFeedHints(group int, name string) feedScheduleHints {
+	feedHintsMutex.Lock()
+	defer feedHintsMutex.Unlock()
+	return feedHints[getFeedKey(group, name)]
+}
+
+// Parses a Retry-After header, either seconds or an HTTP date.
+func parseRetryAfter(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Now().Add(time.Duration(secs) * time.Second)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date
+	}
+	return time.Time{}
+}
+
+// Typical time between posts, from the median gap of the most recent items. Returns 0 if there's too little to go on.
+func getFeedCadence(times []time.Time) time.Duration {
+	var sorted []time.Time
+	for _, t := range times {
+		if !t.IsZero() {
+			sorted = append(sorted, t)
+		}
+	}
+	if len(sorted) < 3 {
+		return 0
+	}
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].After(sorted[b]) })
+	if len(sorted) > 20 {
+		sorted = sorted[:20]
+	}
+	var gaps []time.Duration
+	for k := 0; k < len(sorted)-1; k++ {
+		gaps = append(gaps, sorted[k].Sub(sorted[k+1]))
+	}
+	sort.Slice(gaps, func(a, b int) bool { return gaps[a] < gaps[b] })
+	return gaps[len(gaps)/2]
+}
+
+// Parses a schedule expression onto the feed, logging and ignoring it if invalid.
+func setFeedSchedule(feed *feedThread, expression string) {
+	if expression == "" {
+		return
+	}
+	schedule, err := parseFeedSchedule(expression)
+	if err != nil {
+		log.Println(color.HiRedString("%s \"%s\" has an invalid schedule \"%s\", using waitMins: %s",
+			getFeedTypeName(feed.Group), feed.Name, expression, err))
+		return
+	}
+	feed.Schedule = schedule
+	feed.ScheduleLabel = expression
+}
+
+func getFeedJitter() time.Duration {
+	if generalConfig.FeedJitterSecs <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(generalConfig.FeedJitterSecs) * int64(time.Second)))
+}
+
+// How long to wait before the first run, spread over the first interval so feeds don't all fire at launch.
+// Feeds that ran before a restart resume when they were due.
+func getFeedStartWait(feed *feedThread) time.Duration {
+	if feed.NextRun.After(time.Now()) {
+		return time.Until(feed.NextRun)
+	}
+	stagger := generalConfig.FeedStartupStagger == nil || *generalConfig.FeedStartupStagger
+	if feed.Schedule != nil {
+		wait := time.Duration(feed.WaitMins) * time.Minute
+		// Interval schedules are spread like waitMins, cron runs at the times it was given
+		if window, ok := feed.Schedule.(windowSchedule); ok && stagger {
+			return time.Until(window.NextStaggered(time.Now(), wait)) + getFeedJitter()
+		}
+		return time.Until(feed.Schedule.Next(time.Now(), wait)) + getFeedJitter()
+	}
+	if !stagger {
+		return 0
+	}
+	interval := time.Duration(feed.WaitMins) * time.Minute
+	if feed.Adaptive && feed.MinWaitMins > 0 {
+		interval = time.Duration(feed.MinWaitMins) * time.Minute
+	}
+	if interval <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(interval)))
+}
+
+// How long to wait before running the feed again.
+func getFeedNextWait(feed *feedThread) time.Duration {
+	wait := time.Duration(feed.WaitMins) * time.Minute
+	hints := getFeedHints(feed.Group, feed.Name)
+
+	if feed.Schedule != nil {
+		wait = time.Until(feed.Schedule.Next(time.Now(), wait))
+	} else if feed.Adaptive {
+		minWait := time.Duration(feed.MinWaitMins) * time.Minute
+		maxWait := time.Duration(feed.MaxWaitMins) * time.Minute
+		if minWait <= 0 {
+			minWait = 5 * time.Minute
+		}
+		if maxWait <= 0 {
+			maxWait = 24 * time.Hour
+		}
+		if maxWait < minWait {
+			maxWait = minWait
+		}
+		// Learn from the feed's own activity, busy = often, dead = rarely
+		if cadence := getFeedCadence(hints.ItemTimes); cadence > 0 {
+			wait = cadence / 2
+			var newest time.Time
+			for _, t := range hints.ItemTimes {
+				if t.After(newest) {
+					newest = t
+				}
+			}
+			if sinceNewest := time.Since(newest) / 4; sinceNewest > wait {
+				wait = sinceNewest
+			}
+		}
+		if wait < minWait {
+			wait = minWait
+		} else if wait > maxWait {
+			wait = maxWait
+		}
+		// Respect what the source asks for
+		if hints.MinInterval > wait {
+			wait = hints.MinInterval
+		}
+	}
+
+	if wait <= 0 {
+		wait = time.Minute
+	}
+	wait = getFeedBackoff(feed, wait)
+	next := time.Now().Add(wait)
+	if hints.RetryAfter.After(next) {
+		next = hints.RetryAfter
+	}
+	// Push past skipped hours/days, top of the next hour at a time
+	if len(hints.SkipHours) > 0 || len(hints.SkipDays) > 0 {
+		for attempts := 0; attempts < 24*7 && isFeedSkipTime(hints, next); attempts++ {
+			next = next.UTC().Truncate(time.Hour).Add(time.Hour)
+		}
+	}
+	return time.Until(next) + getFeedJitter()
+}
+
+func isFeedSkipTime(hints feedScheduleHints, t time.Time) bool {
+	t = t.UTC()
+	for _, hour := range hints.SkipHours {
+		if t.Hour() == hour {
+			return true
+		}
+	}
+	for _, day := range hints.SkipDays {
+		if t.Weekday() == day {
+			return true
+		}
+	}
+	return false
+}
+
+func getFeedIntervalLabel(feed feedThread) string {
+	if feed.Failures > 0 && !feed.Paused && !feed.NextRun.IsZero() {
+		return "backing off, next " + humanize.Time(feed.NextRun)
+	}
+	if feed.Schedule != nil {
+		if feed.NextRun.IsZero() {
+			return "\"" + feed.ScheduleLabel + "\""
+		}
+		return "\"" + feed.ScheduleLabel + "\", next " + humanize.Time(feed.NextRun)
+	}
+	if feed.Adaptive {
+		if feed.NextRun.IsZero() {
+			return "adaptive"
+		}
+		return "adaptive, next " + humanize.Time(feed.NextRun)
+	}
+	return fmt.Sprintf("every %d minute%s", feed.WaitMins, ssuff(feed.WaitMins))
+}
+
+//#endregion
+
+// Saves the state of every live feed, for shutdown.
+func saveAllFeedStates() {
+	for _, feed := range getFeedsSnapshot() {
+		saveFeedState(&feed)
+	}
+}
+
+//#region Watchdog
+
+func getFeedRunTimeout() time.Duration {
+	if generalConfig.FeedRunTimeoutMins > 0 {
+		return time.Duration(generalConfig.FeedRunTimeoutMins) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
+// Runs a feed's handler so a panic or hang only affects that feed. Panics are returned as errors with the
+// stack logged, a handler still going past the deadline is abandoned so the group's dispatcher can move on, the feed's
+// next run waits for it to return.
+func runFeedHandler(feed feedThread, handler func() error) error {
+	l := logInstructions{
+		Location: "runFeedHandler",
+		Task:     fmt.Sprintf("%s \"%s\"", getFeedTypeName(feed.Group), feed.Name),
+		Inline:   false,
+		Color:    color.RedString,
+	}
+	if !beginFeedRun(feed) {
+		close(feed.HandlerDone)
+		return errFeedRunSkipped
+	}
+	result := make(chan error, 1) // buffered so an abandoned handler can still finish
+	go func() {
+		defer close(feed.HandlerDone)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Println(l.SetFlag(&lErrorInf).Log("PANIC: %v\n%s", r, debug.Stack()))
+				result <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		result <- handler()
+	}()
+
+	timeout := getFeedRunTimeout()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("run timed out after %s, abandoned", timeout)
+	}
+}
+
+var errFeedRunSkipped = errors.New("feed was deleted or replaced before it ran")
+
+// Checks for feed routines that are stuck waiting on a run their group's dispatcher already started, and replaces them.
+// Feeds still queued for their group's dispatcher aren't running yet and are left alone.
+func runFeedWatchdog() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctxSchedule.Done():
+			return
+		case <-ticker.C:
+		}
+		grace := getFeedRunTimeout() + 5*time.Minute
+		var alerts []string
+		feedsMutex.Lock()
+		for _, feed := range feeds {
+			if feed.Deleted || feed.Paused {
+				continue
+			}
+			if feed.Running && time.Since(feed.LastRan) > grace {
+				reason := fmt.Sprintf("has been running for %s", humanize.RelTime(feed.LastRan, time.Now(), "", ""))
+				feed.LastError = "restarted by watchdog, " + reason
+				feed.LastErrorAt = time.Now()
+				alerts = append(alerts, fmt.Sprintf("⚠️ %s `%s` %s and looks stuck, restarting it...",
+					getFeedTypeName(feed.Group), feed.Name, reason))
+				restartFeed(feed)
+			}
+		}
+		feedsMutex.Unlock()
+		for _, alert := range alerts {
+			notifyAdmins(alert)
+		}
+	}
+}
+
+// Replaces the feed's routine, callers must hold feedsMutex. The old routine exits once its run returns.
+func restartFeed(feed *feedThread) {
+	feed.Generation++
+	feed.Running = false
+	feed.NextRun = time.Now()
+	go startFeed(feed)
+}
+
+//#endregion
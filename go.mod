@@ -4,6 +4,7 @@ go 1.19
 
 require (
 	github.com/Davincible/goinsta v0.0.0-20220425072628-96aad7267204
+	github.com/andybalholm/brotli v1.0.5
 	github.com/bwmarrin/discordgo v0.27.1
 	github.com/dustin/go-humanize v1.0.1
 	github.com/fatih/color v1.15.0
@@ -11,6 +12,7 @@ require (
 	github.com/hako/durafmt v0.0.0-20210608085754-5c1018a4e16b
 	github.com/mmcdole/gofeed v1.2.1
 	github.com/n0madic/twitter-scraper v0.0.0-20230711213008-94503a2bc36c
+	golang.org/x/net v0.14.0
 	gopkg.in/ini.v1 v1.67.0
 	gorm.io/driver/sqlite v1.5.2
 	gorm.io/gorm v1.25.2
@@ -39,7 +41,6 @@ require (
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/stretchr/testify v1.8.4 // indirect
 	golang.org/x/crypto v0.12.0 // indirect
-	golang.org/x/net v0.14.0 // indirect
 	golang.org/x/sys v0.11.0 // indirect
 	golang.org/x/text v0.12.0 // indirect
 )
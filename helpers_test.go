@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Davincible/goinsta"
+	"github.com/bwmarrin/discordgo"
+	"github.com/gtuk/discordwebhook"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Swaps the reference log for an empty in-memory database for the length of the test.
+func setupTestDatabase(t *testing.T) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", url.QueryEscape(t.Name()))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open test database: %s", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to open test database: %s", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&dbRef{}, &dbMigration{}, &dbFeedCache{}, &dbFeedState{}, &dbPlaylistTrack{}); err != nil {
+		t.Fatalf("failed to migrate test database: %s", err)
+	}
+	previous := dbRefs
+	dbRefs = db
+	t.Cleanup(func() {
+		dbRefs = previous
+		sqlDB.Close()
+	})
+}
+
+// A message executed on a channel's webhook.
+type testWebhookMessage struct {
+	Channel string
+	Message discordwebhook.Message
+}
+
+// Stands in for the Discord API, every channel has a "FEEDBOT" webhook and executed messages are recorded.
+type testDiscord struct {
+	mutex    sync.Mutex
+	messages []testWebhookMessage
+}
+
+func (d *testDiscord) Messages() []testWebhookMessage {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]testWebhookMessage(nil), d.messages...)
+}
+
+// Points the Discord session and webhooks at a local stand-in for the length of the test.
+func setupTestDiscord(t *testing.T) *testDiscord {
+	t.Helper()
+	fake := &testDiscord{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 3 && parts[0] == "channels" && parts[2] == "webhooks" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode([]discordgo.Webhook{{ID: parts[1], Token: "token", Name: "FEEDBOT", ChannelID: parts[1]}})
+		case len(parts) == 3 && parts[0] == "webhooks" && r.Method == http.MethodPost:
+			var message discordwebhook.Message
+			if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fake.mutex.Lock()
+			fake.messages = append(fake.messages, testWebhookMessage{Channel: parts[1], Message: message})
+			id := len(fake.messages)
+			fake.mutex.Unlock()
+			json.NewEncoder(w).Encode(discordgo.Message{ID: fmt.Sprint(id), ChannelID: parts[1]})
+		default:
+			http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+		}
+	}))
+
+	session, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatalf("failed to create test session: %s", err)
+	}
+	previousSession := discord
+	previousChannels, previousWebhooks := discordgo.EndpointChannels, discordgo.EndpointWebhooks
+	discord = session
+	discordgo.EndpointChannels = server.URL + "/channels/"
+	discordgo.EndpointWebhooks = server.URL + "/webhooks/"
+	t.Cleanup(func() {
+		discord = previousSession
+		discordgo.EndpointChannels, discordgo.EndpointWebhooks = previousChannels, previousWebhooks
+		server.Close()
+	})
+	return fake
+}
+
+// Serves Instagram profiles from the recorded responses in testdata/instagram for the length of the test.
+func setupTestInstagramFixtures(t *testing.T) {
+	t.Helper()
+	previous := instagramSourceOverride
+	instagramSourceOverride = instagramFixtureSource{Path: "testdata/instagram"}
+	t.Cleanup(func() { instagramSourceOverride = previous })
+}
+
+// Reads API responses recorded per handle, as returned by Instagram:
+//
+//	<path>/<handle>/user.json		users/{handle}/usernameinfo
+//	<path>/<handle>/feed.json		feed/user/{id}
+//	<path>/<handle>/stories.json	feed/user/{id}/story (optional)
+type instagramFixtureSource struct {
+	Path string
+}
+
+func (source instagramFixtureSource) read(handle string, file string, v interface{}) error {
+	data, err := os.ReadFile(filepath.Join(source.Path, handle, file))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (source instagramFixtureSource) Profile(handle string) (*goinsta.User, error) {
+	var response struct {
+		User *goinsta.User `json:"user"`
+	}
+	if err := source.read(handle, "user.json", &response); err != nil {
+		return nil, fmt.Errorf("failed to read user fixture: %s", err)
+	}
+	if response.User == nil {
+		return nil, errors.New("user fixture has no user")
+	}
+	return response.User, nil
+}
+
+func (source instagramFixtureSource) Feed(user *goinsta.User) ([]*goinsta.Item, error) {
+	var feed goinsta.FeedMedia
+	if err := source.read(user.Username, "feed.json", &feed); err != nil {
+		return nil, fmt.Errorf("failed to read feed fixture: %s", err)
+	}
+	return feed.Items, nil
+}
+
+func (source instagramFixtureSource) Stories(user *goinsta.User) ([]*goinsta.Item, error) {
+	var stories goinsta.StoryMedia
+	if err := source.read(user.Username, "stories.json", &stories); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read stories fixture: %s", err)
+	}
+	return stories.Reel.Items, nil
+}
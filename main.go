@@ -6,22 +6,23 @@ package main
 * RSS
 * Instagram
 * Spotify Artist Releases
-* Flickr
-
-*M System Monitor
-
-*L Twitter Trends
-*L NASA APOD
-*L Plex Titles
-*L Twitch Chat Track
-*L Twitch Live
-*L Spotify Playlist Changes
+* Spotify Playlist Changes
+* Spotify Podcasts
+* Flickr Users & Groups
+* Twitch Live
+* Twitch Chat Track
+* Plex Titles
+* NASA APOD
+* System Monitor
+* Twitter Trends
 
  */
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"math/rand"
 	"os"
 	"os/signal"
 	"runtime"
@@ -38,11 +39,21 @@ var (
 	// General
 	loop         chan os.Signal
 	timeLaunched time.Time
+
+	// Cancelled at shutdown, stops feeds from starting new runs
+	ctxSchedule    context.Context
+	cancelSchedule context.CancelFunc
+	// Cancelled once running feeds finish or the shutdown timeout passes, aborts requests still in flight
+	ctxRoot    context.Context
+	cancelRoot context.CancelFunc
 )
 
 func init() {
 	loop = make(chan os.Signal, 1)
 	timeLaunched = time.Now()
+	ctxRoot, cancelRoot = context.WithCancel(context.Background())
+	ctxSchedule, cancelSchedule = context.WithCancel(ctxRoot)
+	rand.Seed(timeLaunched.UnixNano())
 
 	//#region Initialize Logging
 	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile | log.Lmsgprefix)
@@ -81,9 +92,18 @@ func init() {
 }
 
 var (
+	flickrGroup_Channel      = make(chan feedThread)
+	flickrUser_Channel       = make(chan feedThread)
 	instagramAccount_Channel = make(chan feedThread)
+	apod_Channel             = make(chan feedThread)
+	plexServer_Channel       = make(chan feedThread)
 	rssFeed_Channel          = make(chan feedThread)
+	spotifyArtist_Channel    = make(chan feedThread)
+	spotifyPlaylist_Channel  = make(chan feedThread)
+	spotifyPodcast_Channel   = make(chan feedThread)
+	twitchLive_Channel       = make(chan feedThread)
 	twitterAccount_Channel   = make(chan feedThread)
+	twitterTrends_Channel    = make(chan feedThread)
 )
 
 func getFeedCountLabel(filterGroup int) string {
@@ -167,47 +187,166 @@ func main() {
 	// Spawn Feeds
 	l.Task = "spawning feeds"
 	catalogFeeds()
-	feedsClone := feeds
-	for k := range feedsClone {
-		go startFeed(&feeds[k])
+	for _, feed := range feeds {
+		go startFeed(feed)
 	}
-	go func() {
-		for {
-			select {
-			case instagramAccount_Triggered := <-instagramAccount_Channel:
-				{
-					if err := handleInstagramAccount(instagramAccount_Triggered.Config.(configModuleInstagramAcc)); err != nil {
-						log.Println(l.SetTask("handleInstagramAccount").SetFlag(&lError).Log(
-							"Error handling Instagram Account: %s", err.Error()))
-						l.Clear()
-					}
-				}
-			case rssFeed_Triggered := <-rssFeed_Channel:
-				{
-					if err := handleRssFeed(rssFeed_Triggered.Config.(configModuleRssFeed)); err != nil {
-						log.Println(l.SetTask("handleRssFeed").SetFlag(&lError).Log(
-							"Error handling RSS Feed: %s", err.Error()))
-						l.Clear()
-					}
-				}
-			case twitterAccount_Triggered := <-twitterAccount_Channel:
-				{
-					if err := handleTwitterAcc(twitterAccount_Triggered.Config.(configModuleTwitterAcc)); err != nil {
-						log.Println(l.SetTask("handleTwitterAcc").SetFlag(&lError).Log(
-							"Error handling Twitter Account: %s", err.Error()))
-						l.Clear()
-					}
-				}
-			}
-			time.Sleep(100 * time.Millisecond) // don't wanna loop infinitely with no delay
+	go runFeedWatchdog()
+	go runSessionMonitor()
+	go runTwitchChat()
+	go runSystemMonitor()
+	// Each group gets its own dispatcher, so a slow or hung handler only holds up feeds of the same type
+	go dispatchFeeds(l, flickrGroup_Channel, "handleFlickrGroup", "Flickr Group", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleFlickrFeed)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
 		}
-	}()
+		return handleFlickrGroup(config)
+	})
+	go dispatchFeeds(l, flickrUser_Channel, "handleFlickrUser", "Flickr User", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleFlickrFeed)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleFlickrUser(config)
+	})
+	go dispatchFeeds(l, instagramAccount_Channel, "handleInstagramAccount", "Instagram Account", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleInstagramAcc)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleInstagramAccount(config)
+	})
+	go dispatchFeeds(l, apod_Channel, "handleAPOD", "NASA APOD", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleAPODFeed)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleAPOD(config)
+	})
+	go dispatchFeeds(l, plexServer_Channel, "handlePlexServer", "Plex Server", func(thread feedThread) error {
+		config, ok := thread.Config.(configModulePlexServer)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handlePlexServer(config)
+	})
+	go dispatchFeeds(l, rssFeed_Channel, "handleRssFeed", "RSS Feed", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleRssFeed)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleRssFeed(config)
+	})
+	go dispatchFeeds(l, spotifyArtist_Channel, "handleSpotifyArtist", "Spotify Artist", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleSpotifyArtist)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleSpotifyArtist(config)
+	})
+	go dispatchFeeds(l, spotifyPlaylist_Channel, "handleSpotifyPlaylist", "Spotify Playlist", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleSpotifyPlaylist)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleSpotifyPlaylist(config)
+	})
+	go dispatchFeeds(l, spotifyPodcast_Channel, "handleSpotifyPodcast", "Spotify Podcast", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleSpotifyPodcast)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleSpotifyPodcast(config)
+	})
+	go dispatchFeeds(l, twitchLive_Channel, "handleTwitchLive", "Twitch Live", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleTwitchChannel)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleTwitchLive(config)
+	})
+	go dispatchFeeds(l, twitterAccount_Channel, "handleTwitterAcc", "Twitter Account", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleTwitterAcc)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleTwitterAcc(config)
+	})
+	go dispatchFeeds(l, twitterTrends_Channel, "handleTwitterTrends", "Twitter Trends", func(thread feedThread) error {
+		config, ok := thread.Config.(configModuleTwitterTrends)
+		if !ok {
+			return fmt.Errorf("unexpected config type %T", thread.Config)
+		}
+		return handleTwitterTrends(config)
+	})
 
 	l.Task = "running"
 	// Infinite loop until interrupted
 	signal.Notify(loop, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT, os.Interrupt, os.Kill)
 	<-loop
 	l.Task = "exit"
+	shutdown(l)
+
+	log.Println(l.LogC(color.HiRedString, "Exiting..."))
+}
+
+// Runs the feeds of one group as they're triggered, one at a time, and hands each result back to its feed routine.
+func dispatchFeeds(l logInstructions, channel chan feedThread, task string, label string, handle func(feedThread) error) {
+	for triggered := range channel {
+		err := runFeedHandler(triggered, func() error {
+			return handle(triggered)
+		})
+		if err != nil {
+			log.Println(l.SetTask(task).SetFlag(&lError).Log(
+				"Error handling %s: %s", label, err.Error()))
+			l.Clear()
+		}
+		triggered.Result <- err
+		time.Sleep(100 * time.Millisecond) // don't wanna loop infinitely with no delay
+	}
+}
+
+// Stops scheduling feeds, waits for runs in progress to finish sending & logging, then aborts whatever's left and saves everything.
+func shutdown(l logInstructions) {
+	timeout := 30 * time.Second
+	if generalConfig.ShutdownTimeoutSecs > 0 {
+		timeout = time.Duration(generalConfig.ShutdownTimeoutSecs) * time.Second
+	}
+
+	log.Println(l.Log("Shutting down, waiting up to %s for running feeds to finish... (interrupt again to force)", timeout))
+	cancelSchedule()
+
+	done := make(chan struct{})
+	go func() {
+		feedsRunning.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		log.Println(l.Log("Running feeds finished..."))
+	case <-time.After(timeout):
+		log.Println(l.SetFlag(&lWarning).Log("Timed out waiting for running feeds, some items may be sent again next launch..."))
+		l.ClearFlag()
+	case <-loop:
+		log.Println(l.SetFlag(&lWarning).Log("Forcing shutdown..."))
+		l.ClearFlag()
+	}
+	cancelRoot() // requests from runs still going past the timeout
+
+	log.Println(l.Log("Saving feed states & cookies..."))
+	saveAllFeedStates()
+	if twitterConnected {
+		if err := exportTwitterCookies(); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Failed to save Twitter (X) cookies: %s", err))
+			l.ClearFlag()
+		}
+	}
+	if instagramConnected && instagramScraper != nil {
+		if err := instagramScraper.Export(pathDataCookiesInstagram); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Failed to save Instagram cookies: %s", err))
+			l.ClearFlag()
+		}
+	}
 
 	if discordConfig.DeleteCommands {
 		deleteSlashCommands()
@@ -216,7 +355,9 @@ func main() {
 	log.Println(l.Log("Logging out of discord..."))
 	discord.Close()
 
-	log.Println(l.LogC(color.HiRedString, "Exiting..."))
+	if sqlDB, err := dbRefs.DB(); err == nil {
+		sqlDB.Close()
+	}
 }
 
 func openAPIs() map[string]error {
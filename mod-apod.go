@@ -0,0 +1,555 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/fatih/color"
+	"github.com/gtuk/discordwebhook"
+)
+
+var (
+	pathConfigModuleAPOD = pathConfigModules + string(os.PathSeparator) + "apod.json"
+	apodConfig           configModuleAPOD
+
+	moduleNameAPOD = "nasa-apod"
+
+	apodLogo = "https://upload.wikimedia.org/wikipedia/commons/thumb/e/e5/NASA_logo.svg/240px-NASA_logo.svg.png"
+
+	apodDefaultAPIBase  = "https://api.nasa.gov/planetary/apod"
+	apodDefaultSchedule = "0 * * * *" // hourly, only a new day's picture is posted
+)
+
+var (
+	nasaAPIKey string // DEMO_KEY if empty, which is heavily rate limited
+)
+
+type configModuleAPOD struct {
+	APIBase string `json:"apiBase,omitempty"` // default https://api.nasa.gov/planetary/apod
+	APIKey  string `json:"apiKey,omitempty"`  // nasa_api_key in credentials.ini if empty
+
+	WaitMins int    `json:"waitMins,omitempty"`
+	Schedule string `json:"schedule,omitempty"` // schedule expression, see schedule.go, default hourly
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
+	DefaultColor string `json:"defaultColor,omitempty"`
+
+	Feeds []configModuleAPODFeed `json:"feeds"`
+}
+
+type configModuleAPODFeed struct {
+	// MAIN
+	Name         string            `json:"name"`
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins *int   `json:"waitMins,omitempty"`
+	Schedule string `json:"schedule,omitempty"`
+	Proxy    string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	PreferHD          *bool `json:"preferHD,omitempty"`          // default true
+	ExplanationLength *int  `json:"explanationLength,omitempty"` // default 1000, 0 to leave out
+}
+
+func loadConfig_Module_APOD() error {
+	prefixHere := "loadConfig_Module_APOD(): "
+	// TODO: Creation prompts if missing
+
+	// LOAD JSON CONFIG
+	if _, err := os.Stat(pathConfigModuleAPOD); err != nil {
+		return fmt.Errorf("apod config file not found: %s", err)
+	} else {
+		configBytes, err := os.ReadFile(pathConfigModuleAPOD)
+		if err != nil {
+			return fmt.Errorf("failed to read apod config file: %s", err)
+		} else {
+			// Fix backslashes
+			configStr := string(configBytes)
+			configStr = strings.ReplaceAll(configStr, "\\", "\\\\")
+			for strings.Contains(configStr, "\\\\\\") {
+				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
+			}
+			// Parse
+			if err = json.Unmarshal([]byte(configStr), &apodConfig); err != nil {
+				return fmt.Errorf("failed to parse apod config file: %s", err)
+			}
+			if err = checkProxySettings(apodConfig.Proxy, apodConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid apod proxy settings: %s", err)
+			}
+			// Output?
+			if generalConfig.OutputSettings {
+				redacted := apodConfig
+				if redacted.APIKey != "" {
+					redacted.APIKey = "[hidden]"
+				}
+				s, err := json.MarshalIndent(redacted, "", "\t")
+				if err != nil {
+					log.Println(color.HiRedString(prefixHere+"failed to output...\t%s", err))
+				} else {
+					log.Println(color.HiYellowString(prefixHere+"\n%s", color.YellowString(string(s))))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//#region API
+
+type apodEntry struct {
+	Date         string `json:"date"` // YYYY-MM-DD, US Eastern
+	Title        string `json:"title"`
+	Explanation  string `json:"explanation"`
+	MediaType    string `json:"media_type"` // image, video or other (interactive pages)
+	URL          string `json:"url"`
+	HDURL        string `json:"hdurl"`
+	ThumbnailURL string `json:"thumbnail_url"` // videos only
+	Copyright    string `json:"copyright"`
+}
+
+type apodRateLimitError struct {
+	RetryAfter time.Time
+}
+
+func (e apodRateLimitError) Error() string {
+	return fmt.Sprintf("rate limited by the apod api until %s", e.RetryAfter.Format(time.Kitchen))
+}
+
+var (
+	// Feeds usually run at the same time, they share one fetch.
+	apodCache      apodEntry
+	apodCachedAt   time.Time
+	apodCacheMutex sync.Mutex
+)
+
+func getAPODAPIBase() string {
+	if apodConfig.APIBase != "" {
+		return apodConfig.APIBase
+	}
+	return apodDefaultAPIBase
+}
+
+func getAPODAPIKey() string {
+	if apodConfig.APIKey != "" {
+		return apodConfig.APIKey
+	}
+	if nasaAPIKey != "" {
+		return nasaAPIKey
+	}
+	return "DEMO_KEY"
+}
+
+func getAPODEntry(proxy string) (apodEntry, error) {
+	apodCacheMutex.Lock()
+	defer apodCacheMutex.Unlock()
+	if !apodCachedAt.IsZero() && time.Since(apodCachedAt) < 10*time.Minute {
+		return apodCache, nil
+	}
+
+	client, err := getProxyClient(proxy, 30*time.Second)
+	if err != nil {
+		return apodEntry{}, err
+	}
+	query := url.Values{
+		"api_key": {getAPODAPIKey()},
+		"thumbs":  {"true"},
+	}
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, getAPODAPIBase()+"?"+query.Encode(), nil)
+	if err != nil {
+		return apodEntry{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxRoot.Err() == nil {
+			markProxyFailed(proxy)
+		}
+		return apodEntry{}, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return apodEntry{}, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if retryAfter.IsZero() {
+			retryAfter = time.Now().Add(time.Hour)
+		}
+		return apodEntry{}, apodRateLimitError{RetryAfter: retryAfter}
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Msg   string `json:"msg"`
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &apiError) == nil {
+			if apiError.Msg != "" {
+				return apodEntry{}, fmt.Errorf("apod api error (%s): %s", resp.Status, apiError.Msg)
+			} else if apiError.Error.Message != "" {
+				return apodEntry{}, fmt.Errorf("apod api error (%s): %s", resp.Status, apiError.Error.Message)
+			}
+		}
+		return apodEntry{}, fmt.Errorf("apod api error: %s", resp.Status)
+	}
+
+	var entry apodEntry
+	if err = json.Unmarshal(body, &entry); err != nil {
+		return apodEntry{}, fmt.Errorf("failed to parse apod response: %s", err)
+	}
+	if entry.Date == "" {
+		return apodEntry{}, errors.New("apod response has no date")
+	}
+	apodCache = entry
+	apodCachedAt = time.Now()
+	return entry, nil
+}
+
+// Page on apod.nasa.gov for a date, e.g. 2024-03-09 is ap240309.html.
+func getAPODPageURL(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "https://apod.nasa.gov/apod/astropix.html"
+	}
+	return fmt.Sprintf("https://apod.nasa.gov/apod/ap%s.html", t.Format("060102"))
+}
+
+var (
+	regexYoutubeEmbed = regexp.MustCompile(`^https?://(?:www\.)?youtube(?:-nocookie)?\.com/embed/([\w-]+)`)
+	regexVimeoPlayer  = regexp.MustCompile(`^https?://player\.vimeo\.com/video/(\d+)`)
+)
+
+// APOD gives player embed links for videos, Discord only previews the watch pages.
+func getAPODVideoURL(link string) string {
+	if match := regexYoutubeEmbed.FindStringSubmatch(link); match != nil {
+		return "https://www.youtube.com/watch?v=" + match[1]
+	}
+	if match := regexVimeoPlayer.FindStringSubmatch(link); match != nil {
+		return "https://vimeo.com/" + match[1]
+	}
+	return link
+}
+
+func getAPODColor(specific string) string {
+	apodColor := projectColor             // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		apodColor = generalConfig.DefaultColor
+	}
+	if apodConfig.DefaultColor != "" { // override with apod if present
+		apodColor = apodConfig.DefaultColor
+	}
+	if specific != "" { // override with specific if present
+		apodColor = specific
+	}
+	return apodColor
+}
+
+//#endregion
+
+//#region Feeds
+
+func handleAPOD(feed configModuleAPODFeed) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleAPOD(%s): ", feed.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... NASA APOD \"%s\"", feed.Name))
+		l.ClearFlag()
+	}
+
+	proxy := resolveProxy(feed.Proxy, apodConfig.Proxy, apodConfig.ProxyPool)
+	entry, err := getAPODEntry(proxy)
+	if err != nil {
+		var rateLimit apodRateLimitError
+		if errors.As(err, &rateLimit) {
+			hints := getFeedHints(feedAPOD, feed.Name)
+			hints.RetryAfter = rateLimit.RetryAfter
+			setFeedHints(feedAPOD, feed.Name, hints)
+		}
+		return fmt.Errorf("failed to fetch picture of the day: %s", err)
+	}
+	// Already posted today's, checked before building anything
+	if feedCursorGet(feedAPOD, feed.Name) == entry.Date {
+		return nil
+	}
+
+	ref := "apod://" + entry.Date
+	pageURL := getAPODPageURL(entry.Date)
+	title := strings.TrimSpace(entry.Title)
+
+	embedColor, err := hexdec(getAPODColor(feed.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+	username := "NASA APOD"
+	if feed.Username != "" {
+		username = feed.Username
+	}
+	avatar := apodLogo
+	if feed.Avatar != "" {
+		avatar = feed.Avatar
+	}
+
+	explanationLength := 1000
+	if feed.ExplanationLength != nil {
+		explanationLength = *feed.ExplanationLength
+	}
+	description := ""
+	if explanationLength > 0 {
+		description = truncateText(strings.TrimSpace(entry.Explanation), explanationLength)
+	}
+
+	footerText := "Astronomy Picture of the Day · " + entry.Date
+	if copyright := strings.Join(strings.Fields(entry.Copyright), " "); copyright != "" {
+		footerText += " · © " + copyright
+	}
+	embed := discordwebhook.Embed{
+		Title:       &title,
+		Url:         &pageURL,
+		Description: &description,
+		Color:       &embedColor,
+		Footer: &discordwebhook.Footer{
+			Text:    &footerText,
+			IconUrl: &apodLogo,
+		},
+	}
+	// Videos and interactive pages can't be shown in the embed, their link goes in the content for Discord's preview
+	var content string
+	switch entry.MediaType {
+	case "image":
+		image := entry.URL
+		if entry.HDURL != "" && (feed.PreferHD == nil || *feed.PreferHD) {
+			image = entry.HDURL
+		}
+		embed.Image = &discordwebhook.Image{Url: &image}
+	case "video":
+		if entry.URL != "" {
+			content = "🎬 " + getAPODVideoURL(entry.URL)
+		}
+		if entry.ThumbnailURL != "" {
+			embed.Image = &discordwebhook.Image{Url: &entry.ThumbnailURL}
+		}
+	default:
+		link := pageURL
+		if entry.URL != "" {
+			link = entry.URL
+		}
+		content = "🔭 Today's picture is interactive, view it at " + link
+		if entry.ThumbnailURL != "" {
+			embed.Image = &discordwebhook.Image{Url: &entry.ThumbnailURL}
+		}
+	}
+
+	failed := false
+	for _, destination := range feed.Destinations {
+		if refCheckSentToChannel(ref, destination.Channel) {
+			continue
+		}
+		destContent := content
+		if mentions := getDestinationMentions(destination); mentions != "" {
+			destContent = strings.TrimSpace(mentions + "\n" + destContent)
+		}
+		message := discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Embeds:    &[]discordwebhook.Embed{embed},
+		}
+		if destContent != "" {
+			message.Content = &destContent
+		}
+		// SEND
+		sendAttempts := 0
+	resend:
+		sendAttempts++
+		webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, title)
+		sendItem := refItem{Ref: ref, URL: pageURL, Title: title, Source: feed.Name}
+		if _, err := sendWebhookItem(destination.Channel, sendItem, message, moduleNameAPOD); err != nil {
+			if strings.Contains(err.Error(), "resource is being rate limited") && sendAttempts < 5 {
+				log.Println(l.SetFlag(&lError).Log(
+					"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+				l.ClearFlag()
+				time.Sleep(3 * time.Second)
+				goto resend
+			}
+			log.Println(l.SetFlag(&lError).Log(
+				"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+			l.ClearFlag()
+			failed = true
+			continue
+		}
+		markFeedNewItem(feedAPOD, feed.Name)
+		if generalConfig.Debug2 {
+			log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", title, destination.Channel))
+			l.ClearFlag()
+		}
+	}
+	if !failed { // retried next run otherwise
+		feedCursorSet(feedAPOD, feed.Name, entry.Date)
+	}
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... NASA APOD %s", feed.Name))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+func handleAPODCmdOpts(config *configModuleAPODFeed,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			destClone := config.Destinations
+			for key, destination := range destClone {
+				if destination.Channel == i.ChannelID {
+					config.Destinations[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		config.WaitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		config.Username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		config.Avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		config.Color = opt.StringValue()
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["prefer-hd"]; ok {
+		val := opt.BoolValue()
+		config.PreferHD = &val
+	}
+	if opt, ok := optionMap["explanation-length"]; ok {
+		val := int(opt.IntValue())
+		if val < 0 {
+			return errors.New("explanation length can't be negative")
+		}
+		config.ExplanationLength = &val
+	}
+	return nil
+}
+
+func newAPODFeedThread(feed configModuleAPODFeed) feedThread {
+	thread := feedThread{
+		Group:    feedAPOD,
+		Name:     feed.Name,
+		Ref:      getAPODAPIBase(),
+		Config:   feed,
+		WaitMins: apodConfig.WaitMins,
+	}
+	if feed.WaitMins != nil {
+		thread.WaitMins = *feed.WaitMins
+	}
+	if feed.Enabled != nil {
+		thread.Paused = !*feed.Enabled
+	}
+	if feed.Schedule != "" {
+		setFeedSchedule(&thread, feed.Schedule)
+	} else if apodConfig.Schedule != "" {
+		setFeedSchedule(&thread, apodConfig.Schedule)
+	} else if feed.WaitMins == nil && apodConfig.WaitMins == 0 {
+		setFeedSchedule(&thread, apodDefaultSchedule)
+	}
+	return thread
+}
+
+func getAPODConfigIndex(name string) int {
+	for k, feed := range apodConfig.Feeds {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getAPODConfig(name string) *configModuleAPODFeed {
+	i := getAPODConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &apodConfig.Feeds[i]
+	}
+}
+
+func existsAPODConfig(name string) bool {
+	return getAPODConfig(name) != nil
+}
+
+func updateAPODConfig(name string, config configModuleAPODFeed) bool {
+	feedClone := apodConfig.Feeds
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			apodConfig.Feeds[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteAPODConfig(name string) error {
+	index := getAPODConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		apodConfig.Feeds = append(apodConfig.Feeds[:index], apodConfig.Feeds[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedAPOD) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("apod config does not exist")
+}
+
+func setAPODConfigEnabled(name string, enabled bool) error {
+	config := getAPODConfig(name)
+	if config == nil {
+		return errors.New("apod config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedAPOD, *config)
+	return nil
+}
+
+//#endregion
@@ -1,11 +1,750 @@
 package main
 
-import "os"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/fatih/color"
+	"github.com/gtuk/discordwebhook"
+)
 
 var (
 	pathConfigModuleFlickr = pathConfigModules + string(os.PathSeparator) + "flickr.json"
+	flickrConfig           configModuleFlickr
+
+	moduleNameFlickrUsers  = "flickr-users"
+	moduleNameFlickrGroups = "flickr-groups"
+
+	flickrLogo = "https://upload.wikimedia.org/wikipedia/commons/thumb/4/44/Flickr.svg/240px-Flickr.svg.png"
 )
 
 var (
 	flickrKey string
 )
+
+type configModuleFlickr struct {
+	WaitMins int `json:"waitMins,omitempty"`
+
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on upload activity instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
+	DefaultColor string `json:"defaultColor,omitempty"`
+
+	Users  []configModuleFlickrFeed `json:"users"`
+	Groups []configModuleFlickrFeed `json:"groups"`
+}
+
+// Users and groups are set up the same way, only where the photos come from differs.
+type configModuleFlickrFeed struct {
+	// MAIN
+	Name         string            `json:"name"`
+	ID           string            `json:"id"` // NSID, username/group alias or link
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	Tags        []string `json:"tags,omitempty"`        // only post photos with any of these tags
+	ExcludeTags []string `json:"excludeTags,omitempty"` // skip photos with any of these tags
+}
+
+func loadConfig_Module_Flickr() error {
+	prefixHere := "loadConfig_Module_Flickr(): "
+	// TODO: Creation prompts if missing
+
+	// LOAD JSON CONFIG
+	if _, err := os.Stat(pathConfigModuleFlickr); err != nil {
+		return fmt.Errorf("flickr config file not found: %s", err)
+	} else {
+		configBytes, err := os.ReadFile(pathConfigModuleFlickr)
+		if err != nil {
+			return fmt.Errorf("failed to read flickr config file: %s", err)
+		} else {
+			// Fix backslashes
+			configStr := string(configBytes)
+			configStr = strings.ReplaceAll(configStr, "\\", "\\\\")
+			for strings.Contains(configStr, "\\\\\\") {
+				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
+			}
+			// Parse
+			if err = json.Unmarshal([]byte(configStr), &flickrConfig); err != nil {
+				return fmt.Errorf("failed to parse flickr config file: %s", err)
+			}
+			if err = checkProxySettings(flickrConfig.Proxy, flickrConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid flickr proxy settings: %s", err)
+			}
+			// Output?
+			if generalConfig.OutputSettings {
+				s, err := json.MarshalIndent(flickrConfig, "", "\t")
+				if err != nil {
+					log.Println(color.HiRedString(prefixHere+"failed to output...\t%s", err))
+				} else {
+					log.Println(color.HiYellowString(prefixHere+"\n%s", color.YellowString(string(s))))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//#region API
+
+const flickrAPI = "https://api.flickr.com/services/rest/"
+
+// Sizes to use as the embed image, largest first. Originals are skipped, they can be huge or a different format.
+var flickrImageSizes = []string{"url_k", "url_h", "url_l", "url_c", "url_z", "url_m"}
+
+var (
+	flickrResolved      = make(map[string]string) // config ID to NSID
+	flickrResolvedMutex sync.Mutex
+	flickrLicenses      map[string]string
+	flickrLicensesMutex sync.Mutex
+)
+
+type flickrPhoto struct {
+	ID          string `json:"id"`
+	Owner       string `json:"owner"`
+	OwnerName   string `json:"ownername"`
+	Title       string `json:"title"`
+	Tags        string `json:"tags"` // space separated, normalized
+	License     string `json:"license"`
+	DateUpload  string `json:"dateupload"` // unix seconds
+	DateAdded   string `json:"dateadded"`  // unix seconds, group pools only
+	IconServer  string `json:"iconserver"`
+	IconFarm    int    `json:"iconfarm"`
+	Description struct {
+		Content string `json:"_content"`
+	} `json:"description"`
+	Sizes map[string]string `json:"-"` // filled from the url_* extras
+}
+
+type flickrPhotoPage struct {
+	Photos struct {
+		Photo []json.RawMessage `json:"photo"`
+	} `json:"photos"`
+}
+
+type flickrError struct {
+	Stat    string `json:"stat"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Calls a REST method, decoding the response into v.
+func flickrCall(method string, params url.Values, proxy string, v interface{}) error {
+	if flickrKey == "" {
+		return errors.New("flickr_key is missing from credentials.ini")
+	}
+	client, err := getProxyClient(proxy, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("method", method)
+	query.Set("api_key", flickrKey)
+	query.Set("format", "json")
+	query.Set("nojsoncallback", "1")
+
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, flickrAPI+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxRoot.Err() == nil {
+			markProxyFailed(proxy)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("flickr request failed: %s", resp.Status)
+	}
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	var status flickrError
+	if err := json.Unmarshal(body, &status); err == nil && status.Stat == "fail" {
+		return fmt.Errorf("flickr error %d: %s", status.Code, status.Message)
+	}
+	return json.Unmarshal(body, v)
+}
+
+var flickrNSIDPattern = regexp.MustCompile(`^\d+@N\d+$`)
+
+// Turns a configured ID into an NSID. Accepts an NSID, a photostream or group link, or a username/group alias.
+func resolveFlickrID(input string, group bool, proxy string) (string, error) {
+	input = strings.TrimSpace(input)
+	if flickrNSIDPattern.MatchString(input) {
+		return input, nil
+	}
+	flickrResolvedMutex.Lock()
+	nsid, cached := flickrResolved[input]
+	flickrResolvedMutex.Unlock()
+	if cached {
+		return nsid, nil
+	}
+
+	var result struct {
+		User struct {
+			ID   string `json:"id"`
+			NSID string `json:"nsid"`
+		} `json:"user"`
+		Group struct {
+			ID string `json:"id"`
+		} `json:"group"`
+	}
+	link := input
+	if !strings.Contains(link, "flickr.com") {
+		if group {
+			link = "https://www.flickr.com/groups/" + input
+		} else {
+			link = "https://www.flickr.com/photos/" + input
+		}
+	}
+	var err error
+	if group {
+		err = flickrCall("flickr.urls.lookupGroup", url.Values{"url": {link}}, proxy, &result)
+		nsid = result.Group.ID
+	} else {
+		err = flickrCall("flickr.urls.lookupUser", url.Values{"url": {link}}, proxy, &result)
+		nsid = result.User.ID
+		if err != nil && !strings.Contains(input, "/") { // not a path alias, try it as a screen name
+			err = flickrCall("flickr.people.findByUsername", url.Values{"username": {input}}, proxy, &result)
+			nsid = result.User.NSID
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	if nsid == "" {
+		return "", fmt.Errorf("no flickr %s found for \"%s\"", map[bool]string{true: "group", false: "user"}[group], input)
+	}
+	flickrResolvedMutex.Lock()
+	flickrResolved[input] = nsid
+	flickrResolvedMutex.Unlock()
+	return nsid, nil
+}
+
+// License names by ID, fetched once.
+func getFlickrLicenseName(id string, proxy string) string {
+	flickrLicensesMutex.Lock()
+	defer flickrLicensesMutex.Unlock()
+	if flickrLicenses == nil {
+		var result struct {
+			Licenses struct {
+				License []struct {
+					ID   json.Number `json:"id"`
+					Name string      `json:"name"`
+				} `json:"license"`
+			} `json:"licenses"`
+		}
+		if err := flickrCall("flickr.photos.licenses.getInfo", nil, proxy, &result); err != nil {
+			return ""
+		}
+		flickrLicenses = make(map[string]string)
+		for _, license := range result.Licenses.License {
+			flickrLicenses[license.ID.String()] = license.Name
+		}
+	}
+	return flickrLicenses[id]
+}
+
+func getFlickrPhotos(method string, params url.Values, proxy string) ([]flickrPhoto, error) {
+	params.Set("extras", "date_upload,owner_name,tags,license,description,icon_server,"+strings.Join(flickrImageSizes, ","))
+	params.Set("per_page", "50")
+	var page flickrPhotoPage
+	if err := flickrCall(method, params, proxy, &page); err != nil {
+		return nil, err
+	}
+	var photos []flickrPhoto
+	for _, raw := range page.Photos.Photo {
+		var photo flickrPhoto
+		if err := json.Unmarshal(raw, &photo); err != nil {
+			continue
+		}
+		var extras map[string]interface{}
+		if err := json.Unmarshal(raw, &extras); err == nil {
+			photo.Sizes = make(map[string]string)
+			for _, size := range flickrImageSizes {
+				if link, ok := extras[size].(string); ok {
+					photo.Sizes[size] = link
+				}
+			}
+		}
+		photos = append(photos, photo)
+	}
+	return photos, nil
+}
+
+// When the photo showed up in the feed, group pools go by when it was added rather than uploaded
+// since old photos are added to pools all the time.
+func getFlickrPhotoTime(photo flickrPhoto, group int) int64 {
+	if group == feedFlickrGroup && photo.DateAdded != "" {
+		added, _ := strconv.ParseInt(photo.DateAdded, 10, 64)
+		return added
+	}
+	uploaded, _ := strconv.ParseInt(photo.DateUpload, 10, 64)
+	return uploaded
+}
+
+func getFlickrPhotoImage(photo flickrPhoto) string {
+	for _, size := range flickrImageSizes {
+		if link := photo.Sizes[size]; link != "" {
+			return link
+		}
+	}
+	return ""
+}
+
+func getFlickrBuddyIcon(photo flickrPhoto) string {
+	if photo.IconServer == "" || photo.IconServer == "0" {
+		return "https://www.flickr.com/images/buddyicon.gif"
+	}
+	return fmt.Sprintf("https://farm%d.staticflickr.com/%s/buddyicons/%s.jpg", photo.IconFarm, photo.IconServer, photo.Owner)
+}
+
+// Flickr stores tags lowercased with spaces and punctuation removed, config tags are compared the same way.
+func normalizeFlickrTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' || r == '"' || r == '\'' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(tag)))
+}
+
+func checkFlickrTags(photo flickrPhoto, include []string, exclude []string) bool {
+	tags := make(map[string]bool)
+	for _, tag := range strings.Fields(photo.Tags) {
+		tags[tag] = true
+	}
+	for _, tag := range exclude {
+		if tags[normalizeFlickrTag(tag)] {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, tag := range include {
+		if tags[normalizeFlickrTag(tag)] {
+			return true
+		}
+	}
+	return false
+}
+
+func getFlickrColor(specific string) string {
+	flickrColor := projectColor           // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		flickrColor = generalConfig.DefaultColor
+	}
+	if flickrConfig.DefaultColor != "" { // override with flickr if present
+		flickrColor = flickrConfig.DefaultColor
+	}
+	if specific != "" { // override with specific if present
+		flickrColor = specific
+	}
+	return flickrColor
+}
+
+//#endregion
+
+//#region Feeds
+
+func handleFlickrUser(feed configModuleFlickrFeed) error {
+	return handleFlickrFeed(feed, feedFlickrUser)
+}
+
+func handleFlickrGroup(feed configModuleFlickrFeed) error {
+	return handleFlickrFeed(feed, feedFlickrGroup)
+}
+
+func handleFlickrFeed(feed configModuleFlickrFeed, group int) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleFlickrFeed(%s): ", feed.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	feedType := getFeedTypeName(group)
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... %s \"%s\"", feedType, feed.Name))
+		l.ClearFlag()
+	}
+
+	proxy := resolveProxy(feed.Proxy, flickrConfig.Proxy, flickrConfig.ProxyPool)
+	nsid, err := resolveFlickrID(feed.ID, group == feedFlickrGroup, proxy)
+	if err != nil {
+		return fmt.Errorf("[ID:%s] failed to find %s: %s", feed.ID, strings.ToLower(feedType), err)
+	}
+
+	// Photos, newest first
+	var photos []flickrPhoto
+	module := moduleNameFlickrUsers
+	if group == feedFlickrGroup {
+		module = moduleNameFlickrGroups
+		photos, err = getFlickrPhotos("flickr.groups.pools.getPhotos", url.Values{"group_id": {nsid}}, proxy)
+	} else {
+		photos, err = getFlickrPhotos("flickr.people.getPublicPhotos", url.Values{"user_id": {nsid}}, proxy)
+	}
+	if err != nil {
+		return fmt.Errorf("[ID:%s] failed to fetch photos: %s", nsid, err)
+	}
+	sort.SliceStable(photos, func(i, j int) bool { // oldest to newest
+		return getFlickrPhotoTime(photos[i], group) < getFlickrPhotoTime(photos[j], group)
+	})
+
+	embedColor, err := hexdec(getFlickrColor(feed.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+
+	// The cursor is the newest upload (or pool add) time seen, so existing photos aren't posted on the first run
+	cursor, _ := strconv.ParseInt(feedCursorGet(group, feed.Name), 10, 64)
+	newest := cursor
+	if cursor == 0 && len(photos) == 0 { // nothing up yet, anything from now on is new
+		newest = time.Now().Unix()
+	}
+	var oldestUndelivered int64 // the cursor is held here so failed sends are retried next run
+	var uploadTimes []time.Time
+	for _, photo := range photos {
+		if ctxRoot.Err() != nil { // shutting down, rest will be picked up next launch
+			break
+		}
+		uploaded := getFlickrPhotoTime(photo, group)
+		if uploaded > newest {
+			newest = uploaded
+		}
+		uploadTimes = append(uploadTimes, time.Unix(uploaded, 0))
+		link := fmt.Sprintf("https://www.flickr.com/photos/%s/%s", photo.Owner, photo.ID)
+		item := refItem{
+			Ref:    normalizeURL(link),
+			URL:    normalizeURL(link),
+			Title:  photo.Title,
+			Source: feed.Name,
+		}
+		if cursor == 0 { // first run, just remember what's already up
+			for _, destination := range feed.Destinations {
+				refLogSent(item.Ref, destination.Channel, module)
+			}
+			continue
+		}
+		if uploaded < cursor {
+			continue
+		}
+		if !checkFlickrTags(photo, feed.Tags, feed.ExcludeTags) {
+			continue
+		}
+
+		// Appearance Vars
+		username := photo.OwnerName
+		if feed.Username != "" {
+			username = feed.Username
+		}
+		avatar := getFlickrBuddyIcon(photo)
+		if feed.Avatar != "" {
+			avatar = feed.Avatar
+		}
+		ownerLink := "https://www.flickr.com/photos/" + photo.Owner
+		ownerIcon := getFlickrBuddyIcon(photo)
+		title := photo.Title
+		if title == "" {
+			title = "Untitled"
+		}
+		description := truncateText(htmlToMarkdown(photo.Description.Content), 500)
+		var fields []discordwebhook.Field
+		inline := true
+		if photo.Tags != "" {
+			tagsField := "Tags"
+			tags := truncateText(markdownEscaper.Replace(strings.Join(strings.Fields(photo.Tags), ", ")), 1024)
+			fields = append(fields, discordwebhook.Field{Name: &tagsField, Value: &tags, Inline: &inline})
+		}
+		if license := getFlickrLicenseName(photo.License, proxy); license != "" {
+			licenseField := "License"
+			fields = append(fields, discordwebhook.Field{Name: &licenseField, Value: &license, Inline: &inline})
+		}
+		image := getFlickrPhotoImage(photo)
+		footerText := "Flickr"
+		message := discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Embeds: &[]discordwebhook.Embed{{
+				Title:       &title,
+				Url:         &link,
+				Description: &description,
+				Color:       &embedColor,
+				Author: &discordwebhook.Author{
+					Name:    &photo.OwnerName,
+					Url:     &ownerLink,
+					IconUrl: &ownerIcon,
+				},
+				Fields: &fields,
+				Image:  &discordwebhook.Image{Url: &image},
+				Footer: &discordwebhook.Footer{
+					Text:    &footerText,
+					IconUrl: &flickrLogo,
+				},
+			}},
+		}
+
+		for _, destination := range feed.Destinations {
+			if refCheckSentToChannel(item.Ref, destination.Channel) {
+				continue
+			}
+			if suppressed, err := suppressDuplicate(item, destination.Channel, module); suppressed {
+				if err != nil {
+					log.Println(l.SetFlag(&lError).Log(
+						"Error listing %s as also reported on the original post: %s", feed.Name, err.Error()))
+					l.ClearFlag()
+				}
+				continue
+			}
+			destMessage := message
+			tags := getDestinationMentions(destination)
+			if tags != "" {
+				destMessage.Content = &tags
+			}
+			// SEND
+			sendAttempts := 0
+		resend:
+			sendAttempts++
+			webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, link)
+			if _, err := sendWebhookItem(destination.Channel, item, destMessage, module); err != nil {
+				if strings.Contains(err.Error(), "resource is being rate limited") && sendAttempts < 5 {
+					log.Println(l.SetFlag(&lError).Log(
+						"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+					l.ClearFlag()
+					time.Sleep(3 * time.Second)
+					goto resend
+				}
+				log.Println(l.SetFlag(&lError).Log(
+					"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+				l.ClearFlag()
+				if oldestUndelivered == 0 || uploaded < oldestUndelivered {
+					oldestUndelivered = uploaded
+				}
+			} else {
+				markFeedNewItem(group, feed.Name)
+				if generalConfig.Debug2 {
+					log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", link, destination.Channel))
+					l.ClearFlag()
+				}
+			}
+		}
+	}
+	if oldestUndelivered != 0 && oldestUndelivered < newest {
+		newest = oldestUndelivered
+	}
+	if newest != cursor {
+		feedCursorSet(group, feed.Name, strconv.FormatInt(newest, 10))
+	}
+
+	hints := getFeedHints(group, feed.Name)
+	hints.ItemTimes = uploadTimes
+	setFeedHints(group, feed.Name, hints)
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... %s %s", feedType, feed.Name))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+func handleFlickrCmdOpts(config *configModuleFlickrFeed,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-id"]; ok {
+		config.ID = opt.StringValue()
+	}
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			destClone := config.Destinations
+			for key, destination := range destClone {
+				if destination.Channel == i.ChannelID {
+					config.Destinations[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		config.WaitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		config.Username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		config.Avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		config.Color = opt.StringValue()
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["photo-tags"]; ok {
+		config.Tags = splitFlickrTagsOpt(opt.StringValue())
+	}
+	if opt, ok := optionMap["exclude-tags"]; ok {
+		config.ExcludeTags = splitFlickrTagsOpt(opt.StringValue())
+	}
+	return nil
+}
+
+// Comma separated tags from a command, empty clears them.
+func splitFlickrTagsOpt(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func newFlickrFeedThread(feed configModuleFlickrFeed, group int) feedThread {
+	thread := feedThread{
+		Group:       group,
+		Name:        feed.Name,
+		Ref:         feed.ID,
+		Config:      feed,
+		WaitMins:    flickrConfig.WaitMins,
+		Adaptive:    flickrConfig.Adaptive,
+		MinWaitMins: flickrConfig.MinWaitMins,
+		MaxWaitMins: flickrConfig.MaxWaitMins,
+	}
+	if feed.WaitMins != nil {
+		thread.WaitMins = *feed.WaitMins
+	}
+	if feed.Adaptive != nil {
+		thread.Adaptive = *feed.Adaptive
+	}
+	if feed.MinWaitMins != nil {
+		thread.MinWaitMins = *feed.MinWaitMins
+	}
+	if feed.MaxWaitMins != nil {
+		thread.MaxWaitMins = *feed.MaxWaitMins
+	}
+	if feed.Enabled != nil {
+		thread.Paused = !*feed.Enabled
+	}
+	if feed.Schedule != "" {
+		setFeedSchedule(&thread, feed.Schedule)
+	} else {
+		setFeedSchedule(&thread, flickrConfig.Schedule)
+	}
+	return thread
+}
+
+// Config list a Flickr feed group is kept in.
+func getFlickrConfigList(group int) *[]configModuleFlickrFeed {
+	if group == feedFlickrGroup {
+		return &flickrConfig.Groups
+	}
+	return &flickrConfig.Users
+}
+
+func getFlickrConfigIndex(name string, group int) int {
+	for k, feed := range *getFlickrConfigList(group) {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getFlickrConfig(name string, group int) *configModuleFlickrFeed {
+	i := getFlickrConfigIndex(name, group)
+	if i == -1 {
+		return nil
+	} else {
+		return &(*getFlickrConfigList(group))[i]
+	}
+}
+
+func existsFlickrConfig(name string, group int) bool {
+	return getFlickrConfig(name, group) != nil
+}
+
+func updateFlickrConfig(name string, group int, config configModuleFlickrFeed) bool {
+	list := getFlickrConfigList(group)
+	for key, feed := range *list {
+		if strings.EqualFold(name, feed.Name) {
+			(*list)[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteFlickrConfig(name string, group int) error {
+	index := getFlickrConfigIndex(name, group)
+	if index != -1 {
+		// Remove from loaded config
+		list := getFlickrConfigList(group)
+		*list = append((*list)[:index], (*list)[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, group) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("flickr config does not exist")
+}
+
+func setFlickrConfigEnabled(name string, group int, enabled bool) error {
+	config := getFlickrConfig(name, group)
+	if config == nil {
+		return errors.New("flickr config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, group, *config)
+	return nil
+}
+
+//#endregion
@@ -7,29 +7,68 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Davincible/goinsta"
+	"github.com/bwmarrin/discordgo"
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/gtuk/discordwebhook"
 )
 
 var (
 	pathConfigModuleInstagram = pathConfigModules + string(os.PathSeparator) + "instagram.json"
 	instagramConfig           configModuleInstagram
+
+	moduleNameInstagramAccounts = "instagram-accounts"
+
+	instagramLogo = "https://upload.wikimedia.org/wikipedia/commons/thumb/e/e7/Instagram_logo_2016.svg/132px-Instagram_logo_2016.svg.png"
 )
 
 type configModuleInstagram struct {
-	WaitMins int                        `json:"waitMins,omitempty"`
+	WaitMins int `json:"waitMins,omitempty"`
+
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on account activity instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
+	DefaultColor string `json:"defaultColor,omitempty"`
+
 	Accounts []configModuleInstagramAcc `json:"accounts"`
 }
 
 type configModuleInstagramAcc struct {
-	// Main
-	Name         string   `json:"moduleName"`
-	ID           string   `json:"id"`
-	Destinations []string `json:"destinations"`
+	// MAIN
+	Name         string            `json:"name"`
+	Handle       string            `json:"handle"`
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
 
-	WaitMins *int `json:"waitMins,omitempty"`
+	// GENERIC RULES
+	Blacklist [][]string `json:"blacklist,omitempty"`
+	Whitelist [][]string `json:"whitelist,omitempty"`
+	ListType  string     `json:"listType,omitempty"`
+	// RULES
+	IncludePosts   *bool `json:"includePosts,omitempty"`   // default true
+	IncludeReels   *bool `json:"includeReels,omitempty"`   // default true
+	IncludeStories *bool `json:"includeStories,omitempty"` // default false
 }
 
 func loadConfig_Module_Instagram() error {
@@ -50,10 +89,28 @@ func loadConfig_Module_Instagram() error {
 			for strings.Contains(configStr, "\\\\\\") {
 				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
 			}
+			// Migrate
+			migratedStr, migrated, err := migrateInstagramConfig(configStr)
+			if err != nil {
+				return fmt.Errorf("failed to migrate instagram config file: %s", err)
+			}
 			// Parse
-			if err = json.Unmarshal([]byte(configStr), &instagramConfig); err != nil {
+			if err = json.Unmarshal([]byte(migratedStr), &instagramConfig); err != nil {
 				return fmt.Errorf("failed to parse instagram config file: %s", err)
 			}
+			if err = checkProxySettings(instagramConfig.Proxy, instagramConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid instagram proxy settings: %s", err)
+			}
+			if migrated {
+				if err := os.WriteFile(pathConfigModuleInstagram+".bak", configBytes, 0644); err != nil {
+					return fmt.Errorf("failed to back up instagram config file before migrating: %s", err)
+				}
+				if err := saveModuleConfig(feedInstagramAccount); err != nil {
+					return fmt.Errorf("failed to save migrated instagram config file: %s", err)
+				}
+				log.Println(color.HiYellowString(prefixHere+"migrated instagram config to the current format, old file kept as %s.bak",
+					pathConfigModuleInstagram))
+			}
 			// Output?
 			if generalConfig.OutputSettings {
 				s, err := json.MarshalIndent(instagramConfig, "", "\t")
@@ -69,14 +126,60 @@ func loadConfig_Module_Instagram() error {
 	return nil
 }
 
+// Older instagram.json files named accounts by "moduleName", used "id" for the handle and
+// listed destinations as plain channel IDs, moves them to the same shape as the other modules.
+func migrateInstagramConfig(configStr string) (string, bool, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(configStr), &config); err != nil {
+		return configStr, false, err
+	}
+	accounts, _ := config["accounts"].([]interface{})
+	migrated := false
+	for _, accountVal := range accounts {
+		account, ok := accountVal.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for oldKey, newKey := range map[string]string{"moduleName": "name", "id": "handle"} {
+			if val, exists := account[oldKey]; exists {
+				if _, taken := account[newKey]; !taken {
+					account[newKey] = val
+				}
+				delete(account, oldKey)
+				migrated = true
+			}
+		}
+		destinations, _ := account["destinations"].([]interface{})
+		for k, destination := range destinations {
+			if channel, isString := destination.(string); isString {
+				destinations[k] = map[string]interface{}{"channel": channel}
+				migrated = true
+			}
+		}
+	}
+	if !migrated {
+		return configStr, false, nil
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return configStr, false, err
+	}
+	return string(configBytes), true, nil
+}
+
 var (
 	instagramUsername  string
 	instagramPassword  string
 	instagramConnected bool = false
 
-	instagramScraper *goinsta.Instagram
+	instagramScraper      *goinsta.Instagram
+	instagramProxy        string     // proxy the session is currently set to
+	instagramSessionMutex sync.Mutex // held while the session is fetching or being checked
+	instagramAlerted      bool       // admins were told the login failed
 )
 
+const instagramLoginAttempts = 3
+
 func openInstagram() error {
 	l := logInstructions{
 		Location: "openInstagram",
@@ -87,52 +190,632 @@ func openInstagram() error {
 
 	if instagramUsername == "" || instagramPassword == "" {
 		return errors.New("instagram credentials are incomplete")
-	} else {
-		log.Println(l.LogI(true, "Connecting to Instagram..."))
+	}
+	log.Println(l.LogI(true, "Connecting to Instagram..."))
+	return loginInstagram(true)
+}
 
-		//TODO: Proxy Support
+// Logs in from the cookie cache if allowed, otherwise with the credentials, trying a few times.
+// Cookies are saved after every new login.
+func loginInstagram(useCache bool) error {
+	l := logInstructions{
+		Location: "loginInstagram",
+		Task:     instagramUsername,
+		Inline:   false,
+		Color:    color.MagentaString,
+	}
 
-		// Login Loop
-		instagramLoginCount := 0
-	do_instagram_login:
-		instagramLoginCount++
-		if instagramLoginCount > 1 {
+	instagramConnected = false
+	proxy := resolveProxy("", instagramConfig.Proxy, instagramConfig.ProxyPool)
+	if useCache {
+		if scraper, err := goinsta.Import(pathDataCookiesInstagram); err == nil {
+			instagramScraper = scraper
+			instagramProxy = ""
+			if err := setInstagramProxy(proxy); err != nil {
+				return fmt.Errorf("failed to set proxy: %s", err)
+			}
+			log.Println(l.LogCI(color.HiMagentaString, true, "Connected to %s via cache", instagramUsername))
+			instagramConnected = true
+			return nil
+		}
+	}
+
+	var err error
+	for attempt := 1; attempt <= instagramLoginAttempts; attempt++ {
+		if attempt > 1 {
 			time.Sleep(3 * time.Second)
 		}
-		if instagramScraper, err := goinsta.Import(pathDataCookiesInstagram); err != nil {
-			instagramScraper = goinsta.New(instagramUsername, instagramPassword)
-			if err := instagramScraper.Login(); err != nil {
-				log.Println(l.SetFlag(&lError).Log("Login Error: %s", err.Error()))
-				if instagramLoginCount <= 3 {
-					goto do_instagram_login
+		instagramScraper = goinsta.New(instagramUsername, instagramPassword)
+		instagramProxy = ""
+		if err := setInstagramProxy(proxy); err != nil {
+			return fmt.Errorf("failed to set proxy: %s", err)
+		}
+		if err = instagramScraper.Login(); err != nil {
+			if isProxyError(err) { // bad credentials or a challenge aren't the proxy's fault
+				markProxyFailed(proxy)
+			}
+			log.Println(l.SetFlag(&lError).Log("Login Error: %s", err.Error()))
+			l.ClearFlag()
+			continue
+		}
+		log.Println(l.LogCI(color.HiMagentaString, true, "Connected to %s via new login", instagramUsername))
+		instagramConnected = true
+		if err := instagramScraper.Export(pathDataCookiesInstagram); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Failed to save cookies: %s", err.Error()))
+			l.ClearFlag()
+		}
+		return nil
+	}
+
+	log.Println(l.SetFlag(&lError).Log("Failed to login to Instagram, the bot will not fetch this media..."))
+	l.ClearFlag()
+	return fmt.Errorf("login failed: %s", err)
+}
+
+// Checks the session is still logged in, logging in again if not. Admins are alerted once when that fails.
+func checkInstagramSession() {
+	if instagramUsername == "" || instagramPassword == "" {
+		return
+	}
+	if !instagramSessionMutex.TryLock() { // in use, checked next time
+		return
+	}
+	defer instagramSessionMutex.Unlock()
+
+	if instagramConnected && instagramScraper != nil && instagramScraper.Account != nil {
+		err := instagramScraper.Account.Sync()
+		if err == nil {
+			if err := instagramScraper.Export(pathDataCookiesInstagram); err != nil {
+				log.Println(color.HiRedString("Failed to save Instagram cookies: %s", err))
+			}
+			instagramAlerted = false
+			return
+		}
+		log.Println(color.HiYellowString("Instagram session check failed, logging in again: %s", err))
+	}
+
+	if err := loginInstagram(false); err != nil {
+		if !instagramAlerted {
+			notifyAdmins(fmt.Sprintf("⚠️ Instagram account `%s` couldn't log in after %d attempts and needs attention: `%s`",
+				instagramUsername, instagramLoginAttempts, err))
+			instagramAlerted = true
+		}
+	} else if instagramAlerted {
+		notifyAdmins(fmt.Sprintf("✅ Instagram account `%s` is logged in again.", instagramUsername))
+		instagramAlerted = false
+	}
+}
+
+// Switches the session's proxy, only replacing its transport when the proxy actually changes.
+func setInstagramProxy(proxy string) error {
+	if proxy == instagramProxy {
+		return nil
+	}
+	if proxy == "" {
+		transport, err := getProxyTransport("")
+		if err != nil {
+			return err
+		}
+		instagramScraper.SetHTTPTransport(transport)
+	} else {
+		proxyURL, err := parseProxyURL(proxy)
+		if err != nil {
+			return err
+		}
+		if err := instagramScraper.SetProxy(proxyURL.String(), false, true); err != nil {
+			return err
+		}
+	}
+	instagramProxy = proxy
+	return nil
+}
+
+//#region Sources
+
+// Where profile media is read from, the logged in session or recorded API responses.
+type instagramSource interface {
+	Profile(handle string) (*goinsta.User, error)
+	Feed(user *goinsta.User) ([]*goinsta.Item, error) // posts, carousels and reels
+	Stories(user *goinsta.User) ([]*goinsta.Item, error)
+}
+
+// Used instead of the logged in session when set, tests point it at recorded API responses.
+var instagramSourceOverride instagramSource
+
+func getInstagramSource() (instagramSource, error) {
+	if instagramSourceOverride != nil {
+		return instagramSourceOverride, nil
+	}
+	if !instagramConnected || instagramScraper == nil {
+		return nil, errors.New("instagram is not connected")
+	}
+	return instagramSessionSource{Insta: instagramScraper}, nil
+}
+
+type instagramSessionSource struct {
+	Insta *goinsta.Instagram
+}
+
+func (source instagramSessionSource) Profile(handle string) (*goinsta.User, error) {
+	user, err := source.Insta.Profiles.ByName(handle)
+	if err != nil {
+		if isProxyError(err) {
+			markProxyFailed(instagramProxy)
+		}
+		if message := err.Error(); strings.Contains(message, "login_required") || strings.Contains(message, "challenge_required") {
+			instagramConnected = false // logged out, the session check logs in again
+		}
+	}
+	return user, err
+}
+
+func (source instagramSessionSource) Feed(user *goinsta.User) ([]*goinsta.Item, error) {
+	feed := user.Feed()
+	if !feed.Next() {
+		return nil, errors.New("failed to fetch profile feed")
+	}
+	return feed.Items, nil
+}
+
+func (source instagramSessionSource) Stories(user *goinsta.User) ([]*goinsta.Item, error) {
+	stories, err := user.Stories()
+	if err != nil {
+		return nil, err
+	}
+	return stories.Reel.Items, nil
+}
+
+//#endregion
+
+//#region Media
+
+const (
+	instagramPost = iota
+	instagramReel
+	instagramStory
+)
+
+func getInstagramKindName(kind int) string {
+	switch kind {
+	case instagramReel:
+		return "Reel"
+	case instagramStory:
+		return "Story"
+	}
+	return "Post"
+}
+
+func getInstagramItemKind(item *goinsta.Item, fromStories bool) int {
+	if fromStories {
+		return instagramStory
+	}
+	if item.ProductType == "clips" {
+		return instagramReel
+	}
+	return instagramPost
+}
+
+func getInstagramItemLink(item *goinsta.Item, kind int, handle string) string {
+	switch kind {
+	case instagramReel:
+		return "https://www.instagram.com/reel/" + item.Code + "/"
+	case instagramStory:
+		return fmt.Sprintf("https://www.instagram.com/stories/%s/%d/", handle, item.Pk)
+	}
+	return "https://www.instagram.com/p/" + item.Code + "/"
+}
+
+func getInstagramBestVideo(item goinsta.Item) string {
+	best := ""
+	bestSize := 0
+	for _, video := range item.Videos {
+		if size := video.Width * video.Height; size > bestSize || best == "" {
+			best = video.URL
+			bestSize = size
+		}
+	}
+	return best
+}
+
+// Images for the embeds (every carousel slide, or the cover of a video) and direct links to any videos.
+func getInstagramItemMedia(item *goinsta.Item) (images []string, videos []string) {
+	slides := item.CarouselMedia
+	if len(slides) == 0 {
+		slides = []goinsta.Item{*item}
+	}
+	for _, slide := range slides {
+		if image := slide.Images.GetBest(); image != "" {
+			images = append(images, image)
+		}
+		if video := getInstagramBestVideo(slide); video != "" {
+			videos = append(videos, video)
+		}
+	}
+	return images, videos
+}
+
+func checkInstagramLists(account configModuleInstagramAcc, haystack string) bool {
+	vibeCheck := true
+	if len(account.Blacklist) > 0 && len(account.Whitelist) > 0 && account.ListType != "" {
+		vibeCheck = account.ListType != "wb"
+	} else if len(account.Whitelist) > 0 {
+		vibeCheck = false
+	}
+	checkBlacklist := func(ok bool) bool {
+		for _, row := range account.Blacklist {
+			if ok && containsAll(haystack, row) {
+				ok = false
+			}
+		}
+		return ok
+	}
+	checkWhitelist := func(ok bool) bool {
+		for _, row := range account.Whitelist {
+			if !ok && containsAll(haystack, row) {
+				ok = true
+			}
+		}
+		return ok
+	}
+	if account.ListType == "wb" {
+		return checkBlacklist(checkWhitelist(vibeCheck))
+	}
+	return checkWhitelist(checkBlacklist(vibeCheck))
+}
+
+// Builds the webhook message for a post, reel or story. Carousel slides share the post link so Discord groups them.
+func buildInstagramMessage(item *goinsta.Item, kind int, link string,
+	username string, avatar string, embedColor string) discordwebhook.Message {
+	images, videos := getInstagramItemMedia(item)
+	content := link
+	for _, video := range videos {
+		content += "\n" + video
+	}
+
+	caption := truncateText(item.Caption.Text, 4000)
+	likes := ""
+	if kind != instagramStory && !item.LikeViewCountDisabled {
+		likes = fmt.Sprintf(" - %s like%s", humanize.Comma(int64(item.Likes)), ssuff(item.Likes))
+	}
+	footerText := fmt.Sprintf("%s - %s%s",
+		getInstagramKindName(kind), humanize.Time(time.Unix(item.TakenAt, 0)), likes)
+
+	embed := discordwebhook.Embed{
+		Url:   &link,
+		Color: &embedColor,
+		Footer: &discordwebhook.Footer{
+			Text:    &footerText,
+			IconUrl: &instagramLogo,
+		},
+	}
+	if caption != "" {
+		embed.Description = &caption
+	}
+	embeds := []discordwebhook.Embed{embed}
+	for k := range images {
+		if k >= 10 { // Discord limit
+			break
+		}
+		if k == 0 {
+			embeds[0].Image = &discordwebhook.Image{Url: &images[k]}
+		} else {
+			embeds = append(embeds, discordwebhook.Embed{
+				Url:   &link,
+				Image: &discordwebhook.Image{Url: &images[k]},
+			})
+		}
+	}
+
+	return discordwebhook.Message{
+		Username:  &username,
+		AvatarUrl: &avatar,
+		Content:   &content,
+		Embeds:    &embeds,
+	}
+}
+
+//#endregion
+
+func handleInstagramAccount(account configModuleInstagramAcc) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleInstagramAccount(@%s): ", account.Handle),
+		Task:     "",
+		Inline:   false,
+		Color:    color.MagentaString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Instagram Account \"%s\" @%s", account.Name, account.Handle))
+		l.ClearFlag()
+	}
+
+	// Vars
+	includePosts := account.IncludePosts == nil || *account.IncludePosts
+	includeReels := account.IncludeReels == nil || *account.IncludeReels
+	includeStories := account.IncludeStories != nil && *account.IncludeStories
+
+	source, err := getInstagramSource()
+	if err != nil {
+		return err
+	}
+	if _, isSession := source.(instagramSessionSource); isSession {
+		instagramSessionMutex.Lock()
+		defer instagramSessionMutex.Unlock()
+		if err := setInstagramProxy(resolveProxy(account.Proxy, instagramConfig.Proxy, instagramConfig.ProxyPool)); err != nil {
+			return fmt.Errorf("[ID:%s] failed to set proxy: %s", account.Handle, err.Error())
+		}
+	}
+
+	// User Info
+	user, err := source.Profile(account.Handle)
+	if err != nil {
+		return fmt.Errorf("[ID:%s] failed to fetch instagram user: %s", account.Handle, err.Error())
+	}
+
+	// User Appearance Vars
+	username := user.FullName
+	if username == "" {
+		username = user.Username
+	}
+	if account.Username != "" {
+		username = account.Username
+	}
+	avatar := user.ProfilePicURL
+	if user.HdProfilePicURLInfo.URL != "" {
+		avatar = user.HdProfilePicURLInfo.URL
+	}
+	if account.Avatar != "" {
+		avatar = account.Avatar
+	}
+	userColor := projectColor             // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		userColor = generalConfig.DefaultColor
+	}
+	if instagramConfig.DefaultColor != "" { // override with instagram if present
+		userColor = instagramConfig.DefaultColor
+	}
+	if account.Color != "" { // override with specific if present
+		userColor = account.Color
+	}
+	embedColor, err := hexdec(userColor)
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+
+	// Media
+	type instagramEntry struct {
+		Item *goinsta.Item
+		Kind int
+	}
+	var entries []instagramEntry
+	if includePosts || includeReels {
+		items, err := source.Feed(user)
+		if err != nil {
+			return fmt.Errorf("[ID:%s] %s", account.Handle, err.Error())
+		}
+		for k := len(items) - 1; k >= 0; k-- { // process oldest to newest
+			kind := getInstagramItemKind(items[k], false)
+			if (kind == instagramReel && includeReels) || (kind == instagramPost && includePosts) {
+				entries = append(entries, instagramEntry{items[k], kind})
+			}
+		}
+	}
+	if includeStories {
+		items, err := source.Stories(user)
+		if err != nil {
+			log.Println(l.SetFlag(&lError).Log("Error fetching stories: %s", err.Error()))
+			l.ClearFlag()
+		}
+		for _, item := range items { // already oldest to newest
+			entries = append(entries, instagramEntry{item, instagramStory})
+		}
+	}
+
+	if generalConfig.Debug2 {
+		log.Println(l.SetFlag(&lDebug2).LogI(true, "FEED PARSED ... %d items", len(entries)))
+		l.ClearFlag()
+	}
+
+	// FOREACH Item
+	var itemTimes []time.Time
+	for _, entry := range entries {
+		if ctxRoot.Err() != nil { // shutting down, rest will be picked up next launch
+			break
+		}
+		if entry.Item == nil || (entry.Item.Code == "" && entry.Kind != instagramStory) {
+			continue
+		}
+		itemTimes = append(itemTimes, time.Unix(entry.Item.TakenAt, 0))
+		link := getInstagramItemLink(entry.Item, entry.Kind, user.Username)
+		item := refItem{
+			Ref:    normalizeURL(link),
+			URL:    normalizeURL(link),
+			Title:  entry.Item.Caption.Text,
+			Source: account.Name,
+		}
+
+		if !checkInstagramLists(account, entry.Item.Caption.Text) {
+			continue
+		}
+
+		for _, destination := range account.Destinations {
+			sendAttempts := 0
+			if !refCheckSentToChannel(item.Ref, destination.Channel) {
+				// Same post from another feed?
+				if suppressed, err := suppressDuplicate(item, destination.Channel, moduleNameInstagramAccounts); suppressed {
+					if err != nil {
+						log.Println(l.SetFlag(&lError).Log(
+							"Error listing %s as also reported on the original post: %s", account.Name, err.Error()))
+						l.ClearFlag()
+					}
+					if generalConfig.Debug2 {
+						log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- DUPLICATE SUPPRESSED %s to %s", link, destination.Channel))
+						l.ClearFlag()
+					}
+					continue
+				}
+				message := buildInstagramMessage(entry.Item, entry.Kind, link, username, avatar, embedColor)
+				tags := getDestinationMentions(destination)
+				if tags != "" {
+					content := tags + "\n" + *message.Content
+					message.Content = &content
+				}
+				// SEND
+			resend:
+				sendAttempts++
+				webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, link)
+				_, err = sendWebhookItem(destination.Channel, item, message, moduleNameInstagramAccounts)
+				if err != nil {
+					// we want it to process the rest, so no err return
+					if strings.Contains(err.Error(), "resource is being rate limited") {
+						log.Println(l.SetFlag(&lError).Log(
+							"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+						l.ClearFlag()
+						time.Sleep(3 * time.Second)
+						if sendAttempts < 5 {
+							goto resend
+						} else {
+							log.Println(l.SetFlag(&lError).Log(
+								"%s was rate limited more than 5 times, giving up...", webhookInfo))
+							l.ClearFlag()
+						}
+					} else {
+						log.Println(l.SetFlag(&lError).Log(
+							"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+						l.ClearFlag()
+					}
 				} else {
-					log.Println(l.SetFlag(&lError).Log("Failed to login to Instagram, the bot will not fetch this media..."))
-					l.ClearFlag()
-					return errors.New("login failed")
+					markFeedNewItem(feedInstagramAccount, account.Name)
+					if generalConfig.Debug2 {
+						log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", link, destination.Channel))
+						l.ClearFlag()
+					}
 				}
-			} else {
-				log.Println(l.LogCI(color.HiMagentaString, true, "Connected to %s via new login", instagramUsername))
-				instagramConnected = true
-				defer instagramScraper.Export(pathDataCookiesInstagram)
+			} else if generalConfig.Debug2 {
+				log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- ALREADY SENT %s to %s", link, destination.Channel))
+				l.ClearFlag()
 			}
-		} else {
-			log.Println(l.LogCI(color.HiMagentaString, true, "Connected to %s via cache", instagramUsername))
-			instagramConnected = true
 		}
-		//TODO: Reinforce Proxy Support
+	}
+
+	hints := getFeedHints(feedInstagramAccount, account.Name)
+	hints.ItemTimes = itemTimes
+	setFeedHints(feedInstagramAccount, account.Name, hints)
+
+	if generalConfig.Debug {
+		waitMins := instagramConfig.WaitMins
+		if account.WaitMins != nil {
+			waitMins = *account.WaitMins
+		}
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Instagram Account %s ... waiting %d minutes", account.Name, waitMins))
+		l.ClearFlag()
 	}
 
 	return nil
 }
 
-func handleInstagramAccount(account configModuleInstagramAcc) error {
-	log.Printf(color.HiGreenString("<DEBUG> instagram account event fired: %s"), account.ID)
+func handleInstagramAccCmdOpts(config *configModuleInstagramAcc,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-handle"]; ok {
+		config.Handle = strings.TrimPrefix(opt.StringValue(), "@")
+	}
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			destClone := config.Destinations
+			for key, destination := range destClone {
+				if destination.Channel == i.ChannelID {
+					config.Destinations[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		config.WaitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		config.Username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		config.Avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		config.Color = opt.StringValue()
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["include-posts"]; ok {
+		val := opt.BoolValue()
+		config.IncludePosts = &val
+	}
+	if opt, ok := optionMap["include-reels"]; ok {
+		val := opt.BoolValue()
+		config.IncludeReels = &val
+	}
+	if opt, ok := optionMap["include-stories"]; ok {
+		val := opt.BoolValue()
+		config.IncludeStories = &val
+	}
+	// Optional Vars - Lists
+	if opt, ok := optionMap["blacklist"]; ok {
+		var list []string
+		list = append(list, strings.Split(opt.StringValue(), "|")...)
+		config.Blacklist = append(config.Blacklist, list)
+	}
+	if opt, ok := optionMap["whitelist"]; ok {
+		var list []string
+		list = append(list, strings.Split(opt.StringValue(), "|")...)
+		config.Whitelist = append(config.Whitelist, list)
+	}
+	if opt, ok := optionMap["list-type"]; ok {
+		config.ListType = opt.StringValue()
+	}
 	return nil
 }
 
-/*func handleInstagramAccCmdOpts(config *configModuleInstagramAcc,
-optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
-s *discordgo.Session, i *discordgo.InteractionCreate) error {*/
+func newInstagramAccFeedThread(account configModuleInstagramAcc) feedThread {
+	thread := feedThread{
+		Group:       feedInstagramAccount,
+		Name:        account.Name,
+		Ref:         account.Handle,
+		Config:      account,
+		WaitMins:    instagramConfig.WaitMins,
+		Adaptive:    instagramConfig.Adaptive,
+		MinWaitMins: instagramConfig.MinWaitMins,
+		MaxWaitMins: instagramConfig.MaxWaitMins,
+	}
+	if account.WaitMins != nil {
+		thread.WaitMins = *account.WaitMins
+	}
+	if account.Adaptive != nil {
+		thread.Adaptive = *account.Adaptive
+	}
+	if account.MinWaitMins != nil {
+		thread.MinWaitMins = *account.MinWaitMins
+	}
+	if account.MaxWaitMins != nil {
+		thread.MaxWaitMins = *account.MaxWaitMins
+	}
+	if account.Enabled != nil {
+		thread.Paused = !*account.Enabled
+	}
+	if account.Schedule != "" {
+		setFeedSchedule(&thread, account.Schedule)
+	} else {
+		setFeedSchedule(&thread, instagramConfig.Schedule)
+	}
+	return thread
+}
 
 func getInstagramAccConfigIndex(name string) int {
 	for k, feed := range instagramConfig.Accounts {
@@ -152,15 +835,41 @@ func getInstagramAccConfig(name string) *configModuleInstagramAcc {
 	}
 }
 
-func existsInstagramConfig(name string) bool {
-	for _, feed := range instagramConfig.Accounts {
+func existsInstagramAccConfig(name string) bool {
+	return getInstagramAccConfig(name) != nil
+}
+
+func updateInstagramAccConfig(name string, config configModuleInstagramAcc) bool {
+	feedClone := instagramConfig.Accounts
+	for key, feed := range feedClone {
 		if strings.EqualFold(name, feed.Name) {
+			instagramConfig.Accounts[key] = config
 			return true
 		}
 	}
 	return false
 }
 
-// func updateInstagramAccConfig(name string, config configModuleInstagramAcc) bool {
+func deleteInstagramAccConfig(name string) error {
+	index := getInstagramAccConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		instagramConfig.Accounts = append(instagramConfig.Accounts[:index], instagramConfig.Accounts[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedInstagramAccount) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("instagram account config does not exist")
+}
 
-// func deleteInstagramAccConfig(name string) error
+func setInstagramAccConfigEnabled(name string, enabled bool) error {
+	config := getInstagramAccConfig(name)
+	if config == nil {
+		return errors.New("instagram account config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedInstagramAccount, *config)
+	return nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrateInstagramConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		want     string // compared as JSON, "" if the config is left as is
+		migrated bool
+		wantErr  bool
+	}{
+		{
+			name:   "current shape is left alone",
+			config: `{"accounts":[{"name":"NASA","handle":"nasa","destinations":[{"channel":"1"}]}]}`,
+		},
+		{
+			name:     "old keys are renamed",
+			config:   `{"accounts":[{"moduleName":"NASA","id":"nasa","destinations":[{"channel":"1"}]}]}`,
+			want:     `{"accounts":[{"name":"NASA","handle":"nasa","destinations":[{"channel":"1"}]}]}`,
+			migrated: true,
+		},
+		{
+			name:     "plain channel destinations become objects",
+			config:   `{"accounts":[{"name":"NASA","handle":"nasa","destinations":["1",{"channel":"2","tags":["3"]}]}]}`,
+			want:     `{"accounts":[{"name":"NASA","handle":"nasa","destinations":[{"channel":"1"},{"channel":"2","tags":["3"]}]}]}`,
+			migrated: true,
+		},
+		{
+			name:     "new keys win over old ones",
+			config:   `{"accounts":[{"moduleName":"Old","name":"New","id":"old","handle":"new"}]}`,
+			want:     `{"accounts":[{"name":"New","handle":"new"}]}`,
+			migrated: true,
+		},
+		{
+			name:     "module settings are kept",
+			config:   `{"waitMins":30,"proxy":"direct","accounts":[{"id":"nasa"}]}`,
+			want:     `{"waitMins":30,"proxy":"direct","accounts":[{"handle":"nasa"}]}`,
+			migrated: true,
+		},
+		{
+			name:   "no accounts",
+			config: `{"waitMins":30}`,
+		},
+		{
+			name:    "invalid json",
+			config:  `{"accounts":[`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, migrated, err := migrateInstagramConfig(test.config)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, want error %v", err, test.wantErr)
+			}
+			if migrated != test.migrated {
+				t.Errorf("migrated = %v, want %v", migrated, test.migrated)
+			}
+			want := test.want
+			if want == "" {
+				want = test.config
+			}
+			if test.wantErr {
+				if got != test.config {
+					t.Errorf("config changed on error: %s", got)
+				}
+				return
+			}
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Fatalf("migrated config is not valid json: %s", err)
+			}
+			json.Unmarshal([]byte(want), &wantJSON)
+			if !reflect.DeepEqual(gotJSON, wantJSON) {
+				t.Errorf("config = %s, want %s", got, want)
+			}
+			// The result must load into the current struct
+			var config configModuleInstagram
+			if err := json.Unmarshal([]byte(got), &config); err != nil {
+				t.Errorf("migrated config doesn't load: %s", err)
+			}
+		})
+	}
+}
+
+func TestHandleInstagramAccountFixtures(t *testing.T) {
+	setupTestInstagramFixtures(t)
+
+	yes, no := true, false
+	tests := []struct {
+		name      string
+		account   configModuleInstagramAcc
+		wantLinks []string // sent to the first destination, in order
+		wantErr   string
+	}{
+		{
+			name:    "posts and reels by default, oldest first",
+			account: configModuleInstagramAcc{Name: "NASA", Handle: "nasa"},
+			wantLinks: []string{
+				"https://www.instagram.com/p/CmPost001/",
+				"https://www.instagram.com/p/CmCarousel002/",
+				"https://www.instagram.com/reel/CmReel003/",
+			},
+		},
+		{
+			name:      "reels only",
+			account:   configModuleInstagramAcc{Name: "NASA", Handle: "nasa", IncludePosts: &no},
+			wantLinks: []string{"https://www.instagram.com/reel/CmReel003/"},
+		},
+		{
+			name:    "stories after the feed",
+			account: configModuleInstagramAcc{Name: "NASA", Handle: "nasa", IncludeReels: &no, IncludeStories: &yes},
+			wantLinks: []string{
+				"https://www.instagram.com/p/CmPost001/",
+				"https://www.instagram.com/p/CmCarousel002/",
+				"https://www.instagram.com/stories/nasa/4001/",
+			},
+		},
+		{
+			name:    "blacklisted captions are skipped",
+			account: configModuleInstagramAcc{Name: "NASA", Handle: "nasa", Blacklist: [][]string{{"#sponsored"}}},
+			wantLinks: []string{
+				"https://www.instagram.com/p/CmCarousel002/",
+				"https://www.instagram.com/reel/CmReel003/",
+			},
+		},
+		{
+			name:    "whitelist only lets matches through",
+			account: configModuleInstagramAcc{Name: "NASA", Handle: "nasa", Whitelist: [][]string{{"nebula"}}},
+			wantLinks: []string{
+				"https://www.instagram.com/p/CmCarousel002/",
+			},
+		},
+		{
+			name:      "missing stories fixture is not an error",
+			account:   configModuleInstagramAcc{Name: "ESA", Handle: "esa", IncludeStories: &yes},
+			wantLinks: []string{"https://www.instagram.com/p/CmEsa001/"},
+		},
+		{
+			name:    "unknown handle",
+			account: configModuleInstagramAcc{Name: "Nobody", Handle: "nobody"},
+			wantErr: "failed to fetch instagram user",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupTestDatabase(t)
+			fake := setupTestDiscord(t)
+			test.account.Destinations = []feedDestination{{Channel: "100"}, {Channel: "200", Tags: []string{"300"}}}
+
+			err := handleInstagramAccount(test.account)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var links []string
+			for _, sent := range fake.Messages() {
+				content := *sent.Message.Content
+				if sent.Channel == "200" {
+					if !strings.HasPrefix(content, "<@300>\n") {
+						t.Errorf("second destination wasn't tagged: %q", content)
+					}
+					continue
+				}
+				links = append(links, strings.Split(content, "\n")[0])
+			}
+			if !reflect.DeepEqual(links, test.wantLinks) {
+				t.Errorf("sent %v, want %v", links, test.wantLinks)
+			}
+			if got, want := len(fake.Messages()), 2*len(test.wantLinks); got != want {
+				t.Errorf("sent %d messages, want %d", got, want)
+			}
+
+			// Nothing new on the next run
+			if err := handleInstagramAccount(test.account); err != nil {
+				t.Fatalf("unexpected error on second run: %s", err)
+			}
+			if got, want := len(fake.Messages()), 2*len(test.wantLinks); got != want {
+				t.Errorf("second run sent %d more messages", got-want)
+			}
+		})
+	}
+}
+
+func TestBuildInstagramMessageFixtures(t *testing.T) {
+	source := instagramFixtureSource{Path: "testdata/instagram"}
+	user, err := source.Profile("nasa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := source.Feed(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byCode := make(map[string]int)
+	for k, item := range items {
+		byCode[item.Code] = k
+	}
+
+	t.Run("reel links the best video", func(t *testing.T) {
+		item := items[byCode["CmReel003"]]
+		message := buildInstagramMessage(item, instagramReel, "link", "NASA", "", "0")
+		if want := "link\nhttps://cdn.example.com/nasa/3003_720.mp4"; *message.Content != want {
+			t.Errorf("content = %q, want %q", *message.Content, want)
+		}
+		if got := *(*message.Embeds)[0].Image.Url; got != "https://cdn.example.com/nasa/3003_1080.jpg" {
+			t.Errorf("cover = %s", got)
+		}
+	})
+	t.Run("carousel slides share the link", func(t *testing.T) {
+		item := items[byCode["CmCarousel002"]]
+		message := buildInstagramMessage(item, instagramPost, "link", "NASA", "", "0")
+		if len(*message.Embeds) != 3 {
+			t.Fatalf("%d embeds, want 3", len(*message.Embeds))
+		}
+		for _, embed := range *message.Embeds {
+			if *embed.Url != "link" {
+				t.Errorf("slide links to %s", *embed.Url)
+			}
+		}
+	})
+	t.Run("long captions are cut on a character", func(t *testing.T) {
+		item := *items[byCode["CmPost001"]]
+		item.Caption.Text = strings.Repeat("é", 5000)
+		message := buildInstagramMessage(&item, instagramPost, "link", "NASA", "", "0")
+		caption := *(*message.Embeds)[0].Description
+		if runes := []rune(caption); len(runes) != 4000 || runes[len(runes)-1] != '…' {
+			t.Errorf("caption is %d characters", len(runes))
+		}
+		if !json.Valid([]byte(`"` + caption + `"`)) {
+			t.Error("caption isn't valid utf-8")
+		}
+	})
+}
@@ -0,0 +1,808 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/fatih/color"
+	"github.com/gtuk/discordwebhook"
+)
+
+var (
+	pathConfigModulePlex = pathConfigModules + string(os.PathSeparator) + "plex.json"
+	plexConfig           configModulePlex
+
+	moduleNamePlexServers = "plex-servers"
+
+	plexLogo = "https://upload.wikimedia.org/wikipedia/commons/thumb/7/7b/Plex_logo_2022.svg/240px-Plex_logo_2022.svg.png"
+)
+
+var (
+	plexToken string // used for servers without their own token
+)
+
+type configModulePlex struct {
+	WaitMins int `json:"waitMins,omitempty"`
+
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on how often titles are added instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
+	DefaultColor string `json:"defaultColor,omitempty"`
+
+	Servers []configModulePlexServer `json:"servers"`
+}
+
+type configModulePlexServer struct {
+	// MAIN
+	Name         string            `json:"name"`
+	URL          string            `json:"url"`             // e.g. http://192.168.1.10:32400
+	Token        string            `json:"token,omitempty"` // X-Plex-Token, plex_token in credentials.ini if empty
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	Libraries []string `json:"libraries,omitempty"` // library names or section IDs, all if empty
+	Types     []string `json:"types,omitempty"`     // movie, show, episode, music, all if empty
+}
+
+func loadConfig_Module_Plex() error {
+	prefixHere := "loadConfig_Module_Plex(): "
+	// TODO: Creation prompts if missing
+
+	// LOAD JSON CONFIG
+	if _, err := os.Stat(pathConfigModulePlex); err != nil {
+		return fmt.Errorf("plex config file not found: %s", err)
+	} else {
+		configBytes, err := os.ReadFile(pathConfigModulePlex)
+		if err != nil {
+			return fmt.Errorf("failed to read plex config file: %s", err)
+		} else {
+			// Fix backslashes
+			configStr := string(configBytes)
+			configStr = strings.ReplaceAll(configStr, "\\", "\\\\")
+			for strings.Contains(configStr, "\\\\\\") {
+				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
+			}
+			// Parse
+			if err = json.Unmarshal([]byte(configStr), &plexConfig); err != nil {
+				return fmt.Errorf("failed to parse plex config file: %s", err)
+			}
+			if err = checkProxySettings(plexConfig.Proxy, plexConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid plex proxy settings: %s", err)
+			}
+			// Output?
+			if generalConfig.OutputSettings {
+				redacted := plexConfig
+				redacted.Servers = nil
+				for _, server := range plexConfig.Servers {
+					redacted.Servers = append(redacted.Servers, redactPlexConfig(server))
+				}
+				s, err := json.MarshalIndent(redacted, "", "\t")
+				if err != nil {
+					log.Println(color.HiRedString(prefixHere+"failed to output...\t%s", err))
+				} else {
+					log.Println(color.HiYellowString(prefixHere+"\n%s", color.YellowString(string(s))))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Copy of a server config that's safe to show in Discord or logs.
+func redactPlexConfig(server configModulePlexServer) configModulePlexServer {
+	if server.Token != "" {
+		server.Token = "[hidden]"
+	}
+	return server
+}
+
+//#region API
+
+var (
+	plexMachineIDs      = make(map[string]string) // server url to machine identifier, for app links
+	plexMachineIDsMutex sync.Mutex
+)
+
+type plexMedia struct {
+	VideoResolution string `json:"videoResolution"`
+	AudioCodec      string `json:"audioCodec"`
+}
+
+type plexMetadata struct {
+	RatingKey            string      `json:"ratingKey"`
+	ParentRatingKey      string      `json:"parentRatingKey"`
+	GrandparentRatingKey string      `json:"grandparentRatingKey"`
+	Type                 string      `json:"type"` // movie, show, season, episode, artist, album, track
+	Title                string      `json:"title"`
+	ParentTitle          string      `json:"parentTitle"`
+	GrandparentTitle     string      `json:"grandparentTitle"`
+	Index                int         `json:"index"`
+	ParentIndex          int         `json:"parentIndex"`
+	Summary              string      `json:"summary"`
+	Year                 int         `json:"year"`
+	ParentYear           int         `json:"parentYear"`
+	ContentRating        string      `json:"contentRating"`
+	Rating               float64     `json:"rating"`
+	AudienceRating       float64     `json:"audienceRating"`
+	Thumb                string      `json:"thumb"`
+	ParentThumb          string      `json:"parentThumb"`
+	GrandparentThumb     string      `json:"grandparentThumb"`
+	AddedAt              int64       `json:"addedAt"`
+	LeafCount            int         `json:"leafCount"`
+	LibrarySectionID     json.Number `json:"librarySectionID"`
+	LibrarySectionTitle  string      `json:"librarySectionTitle"`
+	Media                []plexMedia `json:"Media"`
+}
+
+func getPlexServerToken(server configModulePlexServer) string {
+	if server.Token != "" {
+		return server.Token
+	}
+	return plexToken
+}
+
+// GETs a server path, the token is sent as a header so it stays out of logged URLs.
+func plexRequest(server configModulePlexServer, path string, query url.Values, proxy string) (*http.Response, error) {
+	token := getPlexServerToken(server)
+	if token == "" {
+		return nil, errors.New("no plex token, set one for the server or plex_token in credentials.ini")
+	}
+	client, err := getProxyClient(proxy, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	link := strings.TrimRight(server.URL, "/") + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Plex-Token", token)
+	req.Header.Set("X-Plex-Product", projectName)
+	req.Header.Set("X-Plex-Client-Identifier", projectName)
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxRoot.Err() == nil {
+			markProxyFailed(proxy)
+		}
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, errors.New("plex token was rejected")
+		}
+		return nil, fmt.Errorf("plex request failed: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func plexGet(server configModulePlexServer, path string, query url.Values, proxy string, v interface{}) error {
+	resp, err := plexRequest(server, path, query, proxy)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Identifier of the server, needed for links into the Plex web app.
+func getPlexMachineID(server configModulePlexServer, proxy string) (string, error) {
+	plexMachineIDsMutex.Lock()
+	id, cached := plexMachineIDs[server.URL]
+	plexMachineIDsMutex.Unlock()
+	if cached {
+		return id, nil
+	}
+	var identity struct {
+		MediaContainer struct {
+			MachineIdentifier string `json:"machineIdentifier"`
+		} `json:"MediaContainer"`
+	}
+	if err := plexGet(server, "/identity", nil, proxy, &identity); err != nil {
+		return "", err
+	}
+	id = identity.MediaContainer.MachineIdentifier
+	if id == "" {
+		return "", errors.New("no machine identifier returned")
+	}
+	plexMachineIDsMutex.Lock()
+	plexMachineIDs[server.URL] = id
+	plexMachineIDsMutex.Unlock()
+	return id, nil
+}
+
+func getPlexRecentlyAdded(server configModulePlexServer, proxy string) ([]plexMetadata, error) {
+	var result struct {
+		MediaContainer struct {
+			Metadata []plexMetadata `json:"Metadata"`
+		} `json:"MediaContainer"`
+	}
+	query := url.Values{
+		"X-Plex-Container-Start": {"0"},
+		"X-Plex-Container-Size":  {"50"},
+	}
+	if err := plexGet(server, "/library/recentlyAdded", query, proxy, &result); err != nil {
+		return nil, err
+	}
+	return result.MediaContainer.Metadata, nil
+}
+
+// Downloads a poster through the server's transcoder, so it can be attached without exposing the server or token.
+func getPlexPoster(server configModulePlexServer, thumb string, proxy string) (*webhookFile, error) {
+	if thumb == "" {
+		return nil, errors.New("no poster")
+	}
+	query := url.Values{
+		"width":   {"600"},
+		"height":  {"900"},
+		"minSize": {"1"},
+		"url":     {thumb},
+	}
+	resp, err := plexRequest(server, "/photo/:/transcode", query, proxy)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 8*1024*1024))
+	if err != nil {
+		return nil, err
+	}
+	return &webhookFile{Name: "poster.jpg", Data: data}, nil
+}
+
+func getPlexColor(specific string) string {
+	plexColor := projectColor             // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		plexColor = generalConfig.DefaultColor
+	}
+	if plexConfig.DefaultColor != "" { // override with plex if present
+		plexColor = plexConfig.DefaultColor
+	}
+	if specific != "" { // override with specific if present
+		plexColor = specific
+	}
+	return plexColor
+}
+
+//#endregion
+
+//#region Servers
+
+// Config type name a Plex item falls under, see configModulePlexServer.Types.
+func getPlexItemType(item plexMetadata) string {
+	switch item.Type {
+	case "show", "season":
+		return "show"
+	case "artist", "album", "track":
+		return "music"
+	}
+	return item.Type
+}
+
+func checkPlexFilters(server configModulePlexServer, item plexMetadata) bool {
+	if len(server.Libraries) > 0 {
+		found := false
+		for _, library := range server.Libraries {
+			if strings.EqualFold(library, item.LibrarySectionTitle) || library == item.LibrarySectionID.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(server.Types) > 0 {
+		itemType := getPlexItemType(item)
+		for _, allowed := range server.Types {
+			if strings.EqualFold(allowed, itemType) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func getPlexResolution(item plexMetadata) string {
+	if len(item.Media) == 0 {
+		return ""
+	}
+	resolution := strings.ToLower(item.Media[0].VideoResolution)
+	switch {
+	case resolution == "":
+		return ""
+	case resolution == "4k" || resolution == "sd":
+		return strings.ToUpper(resolution)
+	case strings.HasSuffix(resolution, "p"):
+		return resolution
+	}
+	return resolution + "p"
+}
+
+func getPlexRating(item plexMetadata) string {
+	var ratings []string
+	if item.AudienceRating > 0 {
+		ratings = append(ratings, fmt.Sprintf("%.1f/10", item.AudienceRating))
+	} else if item.Rating > 0 {
+		ratings = append(ratings, fmt.Sprintf("%.1f/10", item.Rating))
+	}
+	if item.ContentRating != "" {
+		ratings = append(ratings, item.ContentRating)
+	}
+	return strings.Join(ratings, " · ")
+}
+
+// New titles to post together, episodes of the same show or tracks of the same album added in one go are grouped.
+type plexPost struct {
+	Key   string // show/album rating key for groups, the item's own otherwise
+	Items []plexMetadata
+}
+
+func groupPlexItems(items []plexMetadata) []plexPost {
+	var posts []plexPost
+	groups := make(map[string]int)
+	for _, item := range items {
+		key := item.RatingKey
+		switch item.Type {
+		case "episode":
+			key = "show:" + item.GrandparentRatingKey
+		case "track":
+			key = "album:" + item.ParentRatingKey
+		}
+		if index, exists := groups[key]; exists {
+			posts[index].Items = append(posts[index].Items, item)
+			continue
+		}
+		groups[key] = len(posts)
+		posts = append(posts, plexPost{Key: key, Items: []plexMetadata{item}})
+	}
+	return posts
+}
+
+// Title, description lines and poster path of a post.
+func describePlexPost(post plexPost) (string, string, string) {
+	item := post.Items[0]
+	switch item.Type {
+	case "episode":
+		if len(post.Items) == 1 {
+			return item.GrandparentTitle,
+				fmt.Sprintf("**S%02dE%02d** · %s\n\n%s", item.ParentIndex, item.Index, item.Title, truncateText(item.Summary, 500)),
+				item.GrandparentThumb
+		}
+		sort.SliceStable(post.Items, func(i, j int) bool {
+			a, b := post.Items[i], post.Items[j]
+			return a.ParentIndex < b.ParentIndex || (a.ParentIndex == b.ParentIndex && a.Index < b.Index)
+		})
+		var lines []string
+		for _, episode := range post.Items {
+			lines = append(lines, fmt.Sprintf("**S%02dE%02d** · %s", episode.ParentIndex, episode.Index, episode.Title))
+		}
+		return item.GrandparentTitle,
+			fmt.Sprintf("%d new episodes\n%s", len(post.Items), truncateText(strings.Join(lines, "\n"), 3500)),
+			item.GrandparentThumb
+	case "season":
+		description := item.Title
+		if item.LeafCount > 0 {
+			description += fmt.Sprintf(" · %d episode%s", item.LeafCount, ssuff(item.LeafCount))
+		}
+		if item.Summary != "" {
+			description += "\n\n" + truncateText(item.Summary, 500)
+		}
+		return item.ParentTitle, description, item.Thumb
+	case "track":
+		var lines []string
+		for _, track := range post.Items {
+			lines = append(lines, fmt.Sprintf("%d. %s", track.Index, track.Title))
+		}
+		return fmt.Sprintf("%s — %s", item.GrandparentTitle, item.ParentTitle),
+			truncateText(strings.Join(lines, "\n"), 3500), item.ParentThumb
+	case "album":
+		return fmt.Sprintf("%s — %s", item.ParentTitle, item.Title), truncateText(item.Summary, 500), item.Thumb
+	}
+	return item.Title, truncateText(item.Summary, 500), item.Thumb
+}
+
+func handlePlexServer(server configModulePlexServer) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handlePlexServer(%s): ", server.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Plex Server \"%s\"", server.Name))
+		l.ClearFlag()
+	}
+
+	proxy := resolveProxy(server.Proxy, plexConfig.Proxy, plexConfig.ProxyPool)
+	items, err := getPlexRecentlyAdded(server, proxy)
+	if err != nil {
+		return fmt.Errorf("failed to fetch recently added: %s", err)
+	}
+	// Refs are keyed on the machine ID, so the run can't go ahead without it
+	machineID, err := getPlexMachineID(server, proxy)
+	if err != nil {
+		return fmt.Errorf("failed to fetch server identity: %s", err)
+	}
+	sort.SliceStable(items, func(i, j int) bool { // oldest to newest
+		return items[i].AddedAt < items[j].AddedAt
+	})
+
+	// The cursor is the newest addedAt seen, so the existing library isn't posted on the first run
+	cursor, _ := strconv.ParseInt(feedCursorGet(feedPlexServer, server.Name), 10, 64)
+	newest := cursor
+	var addedTimes []time.Time
+	var fresh []plexMetadata
+	for _, item := range items {
+		if item.AddedAt > newest {
+			newest = item.AddedAt
+		}
+		addedTimes = append(addedTimes, time.Unix(item.AddedAt, 0))
+		if cursor == 0 || item.AddedAt < cursor || !checkPlexFilters(server, item) {
+			continue
+		}
+		fresh = append(fresh, item)
+	}
+	if cursor == 0 {
+		if newest == 0 { // empty library, anything from now on is new
+			newest = time.Now().Unix()
+		}
+		feedCursorSet(feedPlexServer, server.Name, strconv.FormatInt(newest, 10))
+		return nil
+	}
+
+	embedColor, err := hexdec(getPlexColor(server.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+	username := "Plex"
+	if server.Username != "" {
+		username = server.Username
+	}
+	avatar := plexLogo
+	if server.Avatar != "" {
+		avatar = server.Avatar
+	}
+
+	// Posters are shared by the destinations, fetched once per run
+	posters := make(map[string]*webhookFile)
+	buildMessage := func(post plexPost) (string, discordwebhook.Message, []webhookFile) {
+		item := post.Items[0]
+		title, description, thumb := describePlexPost(post)
+		detailsKey := item.RatingKey
+		switch item.Type {
+		case "episode":
+			if len(post.Items) > 1 {
+				detailsKey = item.GrandparentRatingKey
+			}
+		case "track":
+			detailsKey = item.ParentRatingKey
+		}
+		link := fmt.Sprintf("https://app.plex.tv/desktop/#!/server/%s/details?key=%s",
+			machineID, url.QueryEscape("/library/metadata/"+detailsKey))
+
+		var fields []discordwebhook.Field
+		inline := true
+		addField := func(name string, value string) {
+			if value != "" {
+				fields = append(fields, discordwebhook.Field{Name: &name, Value: &value, Inline: &inline})
+			}
+		}
+		year := item.Year
+		if year == 0 {
+			year = item.ParentYear
+		}
+		if year > 0 {
+			addField("Year", fmt.Sprint(year))
+		}
+		addField("Rating", getPlexRating(item))
+		addField("Library", item.LibrarySectionTitle)
+		addField("Resolution", getPlexResolution(item))
+
+		footerText := "Plex · " + server.Name
+		embed := discordwebhook.Embed{
+			Title:       &title,
+			Url:         &link,
+			Description: &description,
+			Color:       &embedColor,
+			Fields:      &fields,
+			Footer: &discordwebhook.Footer{
+				Text:    &footerText,
+				IconUrl: &plexLogo,
+			},
+		}
+		poster, fetched := posters[thumb]
+		if !fetched {
+			var err error
+			if poster, err = getPlexPoster(server, thumb, proxy); err != nil && generalConfig.Debug {
+				log.Println(l.SetFlag(&lDebug).LogI(true, "No poster for %s: %s", title, err))
+				l.ClearFlag()
+			}
+			posters[thumb] = poster
+		}
+		var files []webhookFile
+		if poster != nil {
+			posterURL := "attachment://" + poster.Name
+			embed.Thumbnail = &discordwebhook.Thumbnail{Url: &posterURL}
+			files = append(files, *poster)
+		}
+		return title, discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Embeds:    &[]discordwebhook.Embed{embed},
+		}, files
+	}
+
+	var oldestUndelivered int64 // the cursor is held here so failed sends are retried next run
+	for _, post := range groupPlexItems(fresh) {
+		if ctxRoot.Err() != nil { // shutting down, rest will be picked up next launch
+			return nil
+		}
+		for _, destination := range server.Destinations {
+			// Each item is logged, so a group that grows later only posts the new ones
+			unsent := plexPost{Key: post.Key}
+			var refs []string
+			for _, item := range post.Items {
+				ref := fmt.Sprintf("plex://%s/%s", machineID, item.RatingKey)
+				if !refCheckSentToChannel(ref, destination.Channel) {
+					unsent.Items = append(unsent.Items, item)
+					refs = append(refs, ref)
+				}
+			}
+			if len(unsent.Items) == 0 {
+				continue
+			}
+			title, destMessage, files := buildMessage(unsent)
+			if mentions := getDestinationMentions(destination); mentions != "" {
+				destMessage.Content = &mentions
+			}
+			// SEND
+			sendAttempts := 0
+		resend:
+			sendAttempts++
+			webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, title)
+			sendItem := refItem{Ref: refs[0], Title: title, Source: server.Name}
+			if _, err := sendWebhookItem(destination.Channel, sendItem, destMessage, moduleNamePlexServers, files...); err != nil {
+				if strings.Contains(err.Error(), "resource is being rate limited") && sendAttempts < 5 {
+					log.Println(l.SetFlag(&lError).Log(
+						"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+					l.ClearFlag()
+					time.Sleep(3 * time.Second)
+					goto resend
+				}
+				log.Println(l.SetFlag(&lError).Log(
+					"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+				l.ClearFlag()
+				for _, item := range unsent.Items {
+					if oldestUndelivered == 0 || item.AddedAt < oldestUndelivered {
+						oldestUndelivered = item.AddedAt
+					}
+				}
+				continue
+			}
+			for _, ref := range refs[1:] {
+				refLogSent(ref, destination.Channel, moduleNamePlexServers)
+			}
+			markFeedNewItem(feedPlexServer, server.Name)
+			if generalConfig.Debug2 {
+				log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", title, destination.Channel))
+				l.ClearFlag()
+			}
+		}
+	}
+	if oldestUndelivered != 0 && oldestUndelivered < newest {
+		newest = oldestUndelivered
+	}
+	if newest != cursor {
+		feedCursorSet(feedPlexServer, server.Name, strconv.FormatInt(newest, 10))
+	}
+
+	hints := getFeedHints(feedPlexServer, server.Name)
+	hints.ItemTimes = addedTimes
+	setFeedHints(feedPlexServer, server.Name, hints)
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Plex Server %s", server.Name))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+func handlePlexServerCmdOpts(config *configModulePlexServer,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-url"]; ok {
+		config.URL = opt.StringValue()
+	}
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			destClone := config.Destinations
+			for key, destination := range destClone {
+				if destination.Channel == i.ChannelID {
+					config.Destinations[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		config.WaitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		config.Username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		config.Avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		config.Color = opt.StringValue()
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["libraries"]; ok {
+		config.Libraries = splitPlexListOpt(opt.StringValue())
+	}
+	if opt, ok := optionMap["types"]; ok {
+		types := splitPlexListOpt(opt.StringValue())
+		for _, t := range types {
+			switch strings.ToLower(t) {
+			case "movie", "show", "episode", "music":
+			default:
+				return fmt.Errorf("unknown type \"%s\", use movie, show, episode or music", t)
+			}
+		}
+		config.Types = types
+	}
+	return nil
+}
+
+// Comma separated values from a command, empty clears them.
+func splitPlexListOpt(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+func newPlexServerFeedThread(server configModulePlexServer) feedThread {
+	thread := feedThread{
+		Group:       feedPlexServer,
+		Name:        server.Name,
+		Ref:         server.URL,
+		Config:      server,
+		WaitMins:    plexConfig.WaitMins,
+		Adaptive:    plexConfig.Adaptive,
+		MinWaitMins: plexConfig.MinWaitMins,
+		MaxWaitMins: plexConfig.MaxWaitMins,
+	}
+	if server.WaitMins != nil {
+		thread.WaitMins = *server.WaitMins
+	}
+	if server.Adaptive != nil {
+		thread.Adaptive = *server.Adaptive
+	}
+	if server.MinWaitMins != nil {
+		thread.MinWaitMins = *server.MinWaitMins
+	}
+	if server.MaxWaitMins != nil {
+		thread.MaxWaitMins = *server.MaxWaitMins
+	}
+	if server.Enabled != nil {
+		thread.Paused = !*server.Enabled
+	}
+	if server.Schedule != "" {
+		setFeedSchedule(&thread, server.Schedule)
+	} else {
+		setFeedSchedule(&thread, plexConfig.Schedule)
+	}
+	return thread
+}
+
+func getPlexServerConfigIndex(name string) int {
+	for k, feed := range plexConfig.Servers {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getPlexServerConfig(name string) *configModulePlexServer {
+	i := getPlexServerConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &plexConfig.Servers[i]
+	}
+}
+
+func existsPlexServerConfig(name string) bool {
+	return getPlexServerConfig(name) != nil
+}
+
+func updatePlexServerConfig(name string, config configModulePlexServer) bool {
+	feedClone := plexConfig.Servers
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			plexConfig.Servers[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deletePlexServerConfig(name string) error {
+	index := getPlexServerConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		plexConfig.Servers = append(plexConfig.Servers[:index], plexConfig.Servers[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedPlexServer) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("plex server config does not exist")
+}
+
+func setPlexServerConfigEnabled(name string, enabled bool) error {
+	config := getPlexServerConfig(name)
+	if config == nil {
+		return errors.New("plex server config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedPlexServer, *config)
+	return nil
+}
+
+//#endregion
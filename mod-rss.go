@@ -1,18 +1,26 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/andybalholm/brotli"
 	"github.com/bwmarrin/discordgo"
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/gtuk/discordwebhook"
 	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/rss"
 )
 
 var (
@@ -26,6 +34,17 @@ type configModuleRSS struct {
 	WaitMins int `json:"waitMins,omitempty"`
 	DayLimit int `json:"dayLimit,omitempty"` // X days = too old, ignored
 
+	TimeoutSecs int `json:"timeoutSecs,omitempty"` // X seconds before a fetch is abandoned, default 30
+	MaxSizeKB   int `json:"maxSizeKB,omitempty"`   // X kilobytes before a feed is rejected as too large, default 10240
+
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on feed hints & activity instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
 	Feeds []configModuleRssFeed `json:"feeds"`
 }
 
@@ -34,8 +53,14 @@ type configModuleRssFeed struct {
 	Name         string            `json:"name"`
 	URL          string            `json:"url"`
 	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
 
-	WaitMins *int `json:"waitMins,omitempty"`
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
 	//IgnoreDate   *bool    `json:"ignoreDate,omitempty"`
 	//DisableInfo  *bool    `json:"disableInfo,omitempty"`
 
@@ -76,6 +101,9 @@ func loadConfig_Module_RSS() error {
 			if err = json.Unmarshal([]byte(configStr), &rssConfig); err != nil {
 				return fmt.Errorf("failed to parse rss config file: %s", err)
 			}
+			if err = checkProxySettings(rssConfig.Proxy, rssConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid rss proxy settings: %s", err)
+			}
 			// Output?
 			if generalConfig.OutputSettings {
 				s, err := json.MarshalIndent(rssConfig, "", "\t")
@@ -103,12 +131,22 @@ func handleRssFeed(feed configModuleRssFeed) error {
 		l.ClearFlag()
 	}
 	//
-	fp := gofeed.NewParser()
-	fp.UserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/65.0.3325.181 Safari/537.36"
-	rss, err := fp.ParseURL(feed.URL)
+	fetched, err := fetchRssFeed(feed.URL, resolveProxy(feed.Proxy, rssConfig.Proxy, rssConfig.ProxyPool))
+	hints := fetched.Hints
+	if err != nil || fetched.NotModified { // keep what was learned from the last full fetch
+		hints = getFeedHints(feedRSS, feed.Name)
+		hints.RetryAfter = fetched.Hints.RetryAfter
+	}
+	setFeedHints(feedRSS, feed.Name, hints)
 	if err != nil {
 		return fmt.Errorf(feed.Name+": error parsing rss feed: %s", err.Error())
+	} else if fetched.NotModified {
+		if generalConfig.Debug2 {
+			log.Println(l.SetFlag(&lDebug2).LogI(true, "FEED NOT MODIFIED ... skipping"))
+			l.ClearFlag()
+		}
 	} else {
+		rss := fetched.Feed
 		username := rss.Title
 		avatar := ""
 
@@ -117,7 +155,7 @@ func handleRssFeed(feed configModuleRssFeed) error {
 			if cachedAvatar, exists := twitterAvatarCache[handle]; exists {
 				avatar = cachedAvatar
 			} else {
-				twitterUser, err := twitterScraper.GetProfile(handle)
+				twitterUser, err := getTwitterProfile(handle)
 				if err != nil {
 					return fmt.Errorf(feed.Name+": feed uses Twitter for appearance but failed to fetch twitter user: %s", err.Error())
 				}
@@ -141,13 +179,21 @@ func handleRssFeed(feed configModuleRssFeed) error {
 		}
 
 		// FOREACH Entry
+		// Validators are only kept once every item made it, else a 304 would hide the rest
+		delivered := true
 		for i := len(rss.Items) - 1; i >= 0; i-- { // process oldest to newest
+			if ctxRoot.Err() != nil { // shutting down, rest will be picked up next launch
+				delivered = false
+				break
+			}
 			entry := rss.Items[i]
-			link := entry.Link
-			// Unwrap Google Links
-			if strings.Contains(link, "&url=") && strings.Contains(link, "&ct=") {
-				link = link[strings.Index(link, "&url=")+5:]
-				link = link[:strings.Index(link, "&ct=")]
+			link := unwrapURL(entry.Link)
+			refs := rssItemRefs(feed, entry)
+			item := refItem{
+				Ref:    refs[0],
+				URL:    normalizeURL(link),
+				Title:  entry.Title,
+				Source: feed.Name,
 			}
 
 			// SETUP CHECK
@@ -232,15 +278,21 @@ func handleRssFeed(feed configModuleRssFeed) error {
 			if vibeCheck { //TODO: AND meets days old criteria
 				for _, destination := range feed.Destinations {
 					sendAttempts := 0
-					if !refCheckSentToChannel(link, destination.Channel) {
-						tags := ""
-						for _, tag := range destination.Tags {
-							if tags == "" {
-								tags = fmt.Sprintf("<@%s>", tag)
-							} else {
-								tags += fmt.Sprintf(", <@%s>", tag)
+					if !refCheckAnySentToChannel(refs, destination.Channel) {
+						// Same story from another feed?
+						if suppressed, err := suppressDuplicate(item, destination.Channel, moduleNameRSS); suppressed {
+							if err != nil {
+								log.Println(l.SetFlag(&lError).Log(
+									"Error listing %s as also reported on the original post: %s", feed.Name, err.Error()))
+								l.ClearFlag()
 							}
+							if generalConfig.Debug2 {
+								log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- DUPLICATE SUPPRESSED %s to %s", link, destination.Channel))
+								l.ClearFlag()
+							}
+							continue
 						}
+						tags := getDestinationMentions(destination)
 						if tags != "" {
 							tags += "\n"
 						}
@@ -251,12 +303,13 @@ func handleRssFeed(feed configModuleRssFeed) error {
 						sendAttempts++
 						webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, link)
 						// SEND
-						err = sendWebhook(destination.Channel, link, discordwebhook.Message{
+						_, err = sendWebhookItem(destination.Channel, item, discordwebhook.Message{
 							Username:  &username,
 							AvatarUrl: &avatar,
 							Content:   &reply,
 						}, moduleNameRSS)
 						if err != nil {
+							delivered = false
 							// we want it to process the rest, so no err return
 							//TODO: implement this universally vvvvvvvv
 							if strings.Contains(err.Error(), "resource is being rate limited") {
@@ -277,9 +330,12 @@ func handleRssFeed(feed configModuleRssFeed) error {
 									"%s encountered an error while sending: %s", webhookInfo, err.Error()))
 								l.ClearFlag()
 							}
-						} else if generalConfig.Debug2 {
-							log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", link, destination.Channel))
-							l.ClearFlag()
+						} else {
+							markFeedNewItem(feedRSS, feed.Name)
+							if generalConfig.Debug2 {
+								log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", link, destination.Channel))
+								l.ClearFlag()
+							}
 						}
 					} else if generalConfig.Debug2 {
 						log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- ALREADY SENT %s to %s", link, destination.Channel))
@@ -288,6 +344,9 @@ func handleRssFeed(feed configModuleRssFeed) error {
 				}
 			}
 		}
+		if delivered {
+			feedCacheSet(feed.URL, fetched.Header.Get("ETag"), fetched.Header.Get("Last-Modified"))
+		}
 	}
 
 	if generalConfig.Debug {
@@ -302,6 +361,184 @@ func handleRssFeed(feed configModuleRssFeed) error {
 	return nil
 }
 
+var (
+	rssUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/65.0.3325.181 Safari/537.36"
+)
+
+type rssFetchResult struct {
+	Feed        *gofeed.Feed
+	NotModified bool // 304, nothing new since the last fetch
+	Header      http.Header
+	Hints       feedScheduleHints
+}
+
+// Fetches and parses a feed, sending the validators from the last fetch so unchanged feeds aren't downloaded again.
+// The new validators are left in the header for the caller to save once the items are delivered.
+func fetchRssFeed(feedURL string, proxy string) (rssFetchResult, error) {
+	var result rssFetchResult
+
+	timeout := 30 * time.Second
+	if rssConfig.TimeoutSecs > 0 {
+		timeout = time.Duration(rssConfig.TimeoutSecs) * time.Second
+	}
+	maxSize := int64(10240) * 1024
+	if rssConfig.MaxSizeKB > 0 {
+		maxSize = int64(rssConfig.MaxSizeKB) * 1024
+	}
+
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("User-Agent", rssUserAgent)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	cache := feedCacheGet(feedURL)
+	if cache.ETag != "" {
+		req.Header.Set("If-None-Match", cache.ETag)
+	}
+	if cache.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cache.LastModified)
+	}
+
+	client, err := getProxyClient(proxy, timeout)
+	if err != nil {
+		return result, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxRoot.Err() == nil {
+			markProxyFailed(proxy)
+		}
+		return result, err
+	}
+	defer resp.Body.Close()
+	result.Header = resp.Header
+	result.Hints.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	if resp.StatusCode == http.StatusNotModified {
+		result.NotModified = true
+		return result, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	// Decompress
+	var body io.Reader = resp.Body
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return result, err
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	case "br":
+		body = brotli.NewReader(resp.Body)
+	}
+
+	// Read, within limit
+	bodyBytes, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return result, err
+	}
+	if int64(len(bodyBytes)) > maxSize {
+		return result, fmt.Errorf("feed is larger than the %s limit", humanize.IBytes(uint64(maxSize)))
+	}
+
+	// Parse, RSS separately to keep the polling hints the universal feed drops
+	if gofeed.DetectFeedType(bytes.NewReader(bodyBytes)) == gofeed.FeedTypeRSS {
+		rssFeed, err := (&rss.Parser{}).Parse(bytes.NewReader(bodyBytes))
+		if err != nil {
+			return result, err
+		}
+		if result.Feed, err = (&gofeed.DefaultRSSTranslator{}).Translate(rssFeed); err != nil {
+			return result, err
+		}
+		getRssFeedHints(rssFeed, &result.Hints)
+	} else {
+		if result.Feed, err = gofeed.NewParser().Parse(bytes.NewReader(bodyBytes)); err != nil {
+			return result, err
+		}
+	}
+	for _, entry := range result.Feed.Items {
+		if entry.PublishedParsed != nil {
+			result.Hints.ItemTimes = append(result.Hints.ItemTimes, *entry.PublishedParsed)
+		} else if entry.UpdatedParsed != nil {
+			result.Hints.ItemTimes = append(result.Hints.ItemTimes, *entry.UpdatedParsed)
+		}
+	}
+
+	return result, nil
+}
+
+// Reads <ttl>, <sy:updatePeriod>/<sy:updateFrequency>, <skipHours> and <skipDays>.
+func getRssFeedHints(feed *rss.Feed, hints *feedScheduleHints) {
+	if ttl, err := strconv.Atoi(strings.TrimSpace(feed.TTL)); err == nil && ttl > 0 {
+		hints.MinInterval = time.Duration(ttl) * time.Minute
+	}
+	if sy, ok := feed.Extensions["sy"]; ok {
+		var period time.Duration
+		if values := sy["updatePeriod"]; len(values) > 0 {
+			switch strings.ToLower(strings.TrimSpace(values[0].Value)) {
+			case "hourly":
+				period = time.Hour
+			case "daily":
+				period = 24 * time.Hour
+			case "weekly":
+				period = 7 * 24 * time.Hour
+			case "monthly":
+				period = 30 * 24 * time.Hour
+			case "yearly":
+				period = 365 * 24 * time.Hour
+			}
+		}
+		frequency := 1
+		if values := sy["updateFrequency"]; len(values) > 0 {
+			if val, err := strconv.Atoi(strings.TrimSpace(values[0].Value)); err == nil && val > 0 {
+				frequency = val
+			}
+		}
+		if interval := period / time.Duration(frequency); interval > hints.MinInterval {
+			hints.MinInterval = interval
+		}
+	}
+	for _, hour := range feed.SkipHours {
+		if val, err := strconv.Atoi(strings.TrimSpace(hour)); err == nil && val >= 0 && val <= 24 {
+			hints.SkipHours = append(hints.SkipHours, val%24)
+		}
+	}
+	for _, day := range feed.SkipDays {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.EqualFold(strings.TrimSpace(day), weekday.String()) {
+				hints.SkipDays = append(hints.SkipDays, weekday)
+			}
+		}
+	}
+}
+
+// Identifying refs for a feed item, preferred first. The GUID is used when present since
+// links can change, the normalized link is still checked for items logged before GUIDs were.
+func rssItemRefs(feed configModuleRssFeed, entry *gofeed.Item) []string {
+	var refs []string
+	if guid := strings.TrimSpace(entry.GUID); guid != "" {
+		if strings.HasPrefix(guid, "http://") || strings.HasPrefix(guid, "https://") {
+			refs = append(refs, normalizeURL(guid))
+		} else { // not globally unique, scope to the feed
+			refs = append(refs, "guid:"+normalizeURL(feed.URL)+"#"+guid)
+		}
+	}
+	if entry.Link != "" {
+		if linkRef := normalizeURL(entry.Link); len(refs) == 0 || refs[0] != linkRef {
+			refs = append(refs, linkRef)
+		}
+	}
+	if len(refs) == 0 {
+		refs = append(refs, "")
+	}
+	return refs
+}
+
 func handleRssCmdOpts(config *configModuleRssFeed,
 	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
 	s *discordgo.Session, i *discordgo.InteractionCreate) error {
@@ -325,6 +562,12 @@ func handleRssCmdOpts(config *configModuleRssFeed,
 		val := int(opt.IntValue())
 		config.WaitMins = &val
 	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
 	if opt, ok := optionMap["avatar"]; ok {
 		config.Avatar = opt.StringValue()
 	}
@@ -356,6 +599,40 @@ func handleRssCmdOpts(config *configModuleRssFeed,
 	return nil
 }
 
+func newRssFeedThread(feed configModuleRssFeed) feedThread {
+	thread := feedThread{
+		Group:       feedRSS,
+		Name:        feed.Name,
+		Ref:         "\"" + feed.URL + "\"",
+		Config:      feed,
+		WaitMins:    rssConfig.WaitMins,
+		Adaptive:    rssConfig.Adaptive,
+		MinWaitMins: rssConfig.MinWaitMins,
+		MaxWaitMins: rssConfig.MaxWaitMins,
+	}
+	if feed.WaitMins != nil {
+		thread.WaitMins = *feed.WaitMins
+	}
+	if feed.Adaptive != nil {
+		thread.Adaptive = *feed.Adaptive
+	}
+	if feed.MinWaitMins != nil {
+		thread.MinWaitMins = *feed.MinWaitMins
+	}
+	if feed.MaxWaitMins != nil {
+		thread.MaxWaitMins = *feed.MaxWaitMins
+	}
+	if feed.Enabled != nil {
+		thread.Paused = !*feed.Enabled
+	}
+	if feed.Schedule != "" {
+		setFeedSchedule(&thread, feed.Schedule)
+	} else {
+		setFeedSchedule(&thread, rssConfig.Schedule)
+	}
+	return thread
+}
+
 func getRssConfigIndex(name string) int {
 	for k, feed := range rssConfig.Feeds {
 		if strings.EqualFold(name, feed.Name) {
@@ -402,3 +679,13 @@ func deleteRssConfig(name string) error {
 	}
 	return errors.New("rss config does not exist")
 }
+
+func setRssConfigEnabled(name string, enabled bool) error {
+	config := getRssConfig(name)
+	if config == nil {
+		return errors.New("rss config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedRSS, *config)
+	return nil
+}
@@ -1,9 +1,35 @@
 package main
 
-import "os"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/fatih/color"
+	"github.com/gtuk/discordwebhook"
+	"github.com/hako/durafmt"
+)
 
 var (
 	pathConfigModuleSpotify = pathConfigModules + string(os.PathSeparator) + "spotify.json"
+	spotifyConfig           configModuleSpotify
+
+	moduleNameSpotifyArtists   = "spotify-artists"
+	moduleNameSpotifyPlaylists = "spotify-playlists"
+	moduleNameSpotifyPodcasts  = "spotify-podcasts"
+
+	spotifyLogo = "https://upload.wikimedia.org/wikipedia/commons/thumb/8/84/Spotify_icon.svg/232px-Spotify_icon.svg.png"
 )
 
 var (
@@ -11,6 +37,1444 @@ var (
 	spotifyClientSecret string
 )
 
+type configModuleSpotify struct {
+	WaitMins int `json:"waitMins,omitempty"`
+
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on release activity instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	Market string `json:"market,omitempty"` // ISO country code releases must be available in, any if empty
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
+	DefaultColor string `json:"defaultColor,omitempty"`
+
+	Artists   []configModuleSpotifyArtist   `json:"artists"`
+	Playlists []configModuleSpotifyPlaylist `json:"playlists,omitempty"`
+	Podcasts  []configModuleSpotifyPodcast  `json:"podcasts,omitempty"`
+}
+
+type configModuleSpotifyArtist struct {
+	// MAIN
+	Name         string            `json:"name"`
+	ID           string            `json:"id"` // artist ID, URI or link
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	IncludeAlbums      *bool `json:"includeAlbums,omitempty"`      // default true
+	IncludeSingles     *bool `json:"includeSingles,omitempty"`     // default true
+	IncludeAppearances *bool `json:"includeAppearances,omitempty"` // default true, releases the artist features on
+}
+
+type configModuleSpotifyPlaylist struct {
+	// MAIN
+	Name         string            `json:"name"`
+	ID           string            `json:"id"` // playlist ID, URI or link
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	IncludeRemovals *bool `json:"includeRemovals,omitempty"` // default false
+}
+
+type configModuleSpotifyPodcast struct {
+	// MAIN
+	Name         string            `json:"name"`
+	ID           string            `json:"id"` // show ID, URI or link
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username          string `json:"username,omitempty"`
+	Avatar            string `json:"avatar,omitempty"`
+	Color             string `json:"color,omitempty"`
+	DescriptionLength *int   `json:"descriptionLength,omitempty"` // characters of the episode description to post, default 500, 0 for none
+}
+
 func loadConfig_Module_Spotify() error {
+	prefixHere := "loadConfig_Module_Spotify(): "
+	// TODO: Creation prompts if missing
+
+	// LOAD JSON CONFIG
+	if _, err := os.Stat(pathConfigModuleSpotify); err != nil {
+		return fmt.Errorf("spotify config file not found: %s", err)
+	} else {
+		configBytes, err := os.ReadFile(pathConfigModuleSpotify)
+		if err != nil {
+			return fmt.Errorf("failed to read spotify config file: %s", err)
+		} else {
+			// Fix backslashes
+			configStr := string(configBytes)
+			configStr = strings.ReplaceAll(configStr, "\\", "\\\\")
+			for strings.Contains(configStr, "\\\\\\") {
+				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
+			}
+			// Parse
+			if err = json.Unmarshal([]byte(configStr), &spotifyConfig); err != nil {
+				return fmt.Errorf("failed to parse spotify config file: %s", err)
+			}
+			if err = checkProxySettings(spotifyConfig.Proxy, spotifyConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid spotify proxy settings: %s", err)
+			}
+			// Output?
+			if generalConfig.OutputSettings {
+				s, err := json.MarshalIndent(spotifyConfig, "", "\t")
+				if err != nil {
+					log.Println(color.HiRedString(prefixHere+"failed to output...\t%s", err))
+				} else {
+					log.Println(color.HiYellowString(prefixHere+"\n%s", color.YellowString(string(s))))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//#region API
+
+const spotifyAPI = "https://api.spotify.com/v1"
+
+var (
+	spotifyToken        string
+	spotifyTokenExpires time.Time
+	spotifyTokenMutex   sync.Mutex
+)
+
+type spotifyImage struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type spotifyExternalURLs struct {
+	Spotify string `json:"spotify"`
+}
+
+type spotifyArtistInfo struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Images       []spotifyImage      `json:"images"`
+	ExternalURLs spotifyExternalURLs `json:"external_urls"`
+}
+
+type spotifyAlbum struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	AlbumType            string              `json:"album_type"`  // album, single, compilation
+	AlbumGroup           string              `json:"album_group"` // same, or appears_on
+	ReleaseDate          string              `json:"release_date"`
+	ReleaseDatePrecision string              `json:"release_date_precision"` // year, month or day
+	TotalTracks          int                 `json:"total_tracks"`
+	Images               []spotifyImage      `json:"images"`
+	ExternalURLs         spotifyExternalURLs `json:"external_urls"`
+	Artists              []spotifyArtistInfo `json:"artists"`
+}
+
+type spotifyUser struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+type spotifyTrack struct {
+	ID           string              `json:"id"`
+	URI          string              `json:"uri"`
+	Type         string              `json:"type"` // track or episode
+	Name         string              `json:"name"`
+	DurationMs   int                 `json:"duration_ms"`
+	ExternalURLs spotifyExternalURLs `json:"external_urls"`
+	Artists      []spotifyArtistInfo `json:"artists"`
+	Album        spotifyAlbum        `json:"album"`
+}
+
+type spotifyPlaylist struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	SnapshotID   string              `json:"snapshot_id"`
+	Images       []spotifyImage      `json:"images"`
+	ExternalURLs spotifyExternalURLs `json:"external_urls"`
+	Owner        spotifyUser         `json:"owner"`
+}
+
+type spotifyPlaylistTrackPage struct {
+	Items []struct {
+		AddedAt time.Time     `json:"added_at"`
+		AddedBy *spotifyUser  `json:"added_by"` // missing on old or Spotify made playlists
+		IsLocal bool          `json:"is_local"`
+		Track   *spotifyTrack `json:"track"` // null if it's been taken down
+	} `json:"items"`
+	Next string `json:"next"`
+}
+
+type spotifyShow struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Publisher    string              `json:"publisher"`
+	Images       []spotifyImage      `json:"images"`
+	ExternalURLs spotifyExternalURLs `json:"external_urls"`
+}
+
+type spotifyEpisode struct {
+	ID              string              `json:"id"`
+	Name            string              `json:"name"`
+	Description     string              `json:"description"`
+	HTMLDescription string              `json:"html_description"`
+	DurationMs      int                 `json:"duration_ms"`
+	Explicit        bool                `json:"explicit"`
+	ReleaseDate     string              `json:"release_date"`
+	Images          []spotifyImage      `json:"images"`
+	ExternalURLs    spotifyExternalURLs `json:"external_urls"`
+}
+
+type spotifyEpisodePage struct {
+	Items []*spotifyEpisode `json:"items"` // null for episodes unavailable in the market
+	Next  string            `json:"next"`
+}
+
+type spotifyAlbumPage struct {
+	Items []spotifyAlbum `json:"items"`
+	Next  string         `json:"next"`
+}
+
+// Returned when Spotify asks to slow down, so the feed can wait as long as it was told to.
+type spotifyRateLimitError struct {
+	RetryAfter time.Time
+}
+
+func (e spotifyRateLimitError) Error() string {
+	return "rate limited by spotify, retry " + humanizeTimeOrNever(e.RetryAfter)
+}
+
+// Client credentials token, refreshed a minute before it expires.
+func getSpotifyToken(client *http.Client) (string, error) {
+	spotifyTokenMutex.Lock()
+	defer spotifyTokenMutex.Unlock()
+	if spotifyToken != "" && time.Now().Before(spotifyTokenExpires) {
+		return spotifyToken, nil
+	}
+	if spotifyClientID == "" || spotifyClientSecret == "" {
+		return "", errors.New("spotify credentials are missing from credentials.ini")
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodPost,
+		"https://accounts.spotify.com/api/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(spotifyClientID, spotifyClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("spotify token request failed (%s): %s", resp.Status, body)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	spotifyToken = token.AccessToken
+	spotifyTokenExpires = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return spotifyToken, nil
+}
+
+// GET against the Web API, path relative to /v1 or a full "next" link from a previous page.
+func spotifyGet(path string, query url.Values, proxy string, v interface{}) error {
+	client, err := getProxyClient(proxy, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	token, err := getSpotifyToken(client)
+	if err != nil {
+		return err
+	}
+
+	link := path
+	if !strings.HasPrefix(link, "https://") {
+		link = spotifyAPI + path
+	}
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxRoot.Err() == nil {
+			markProxyFailed(proxy)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return spotifyRateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	case resp.StatusCode == http.StatusUnauthorized: // token revoked early, fetch a new one next time
+		spotifyTokenMutex.Lock()
+		spotifyToken = ""
+		spotifyTokenMutex.Unlock()
+		return errors.New("spotify token was rejected")
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("spotify request failed (%s): %s", resp.Status, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Accepts an ID, a "spotify:artist:ID" URI or an open.spotify.com link, returning the ID.
+func parseSpotifyID(input string, kind string) string {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "spotify:"+kind+":") {
+		return strings.TrimPrefix(input, "spotify:"+kind+":")
+	}
+	if u, err := url.Parse(input); err == nil && strings.HasSuffix(u.Hostname(), "spotify.com") {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for k := 0; k < len(parts)-1; k++ {
+			if parts[k] == kind {
+				return parts[k+1]
+			}
+		}
+	}
+	return input
+}
+
+// Shows and episodes can't be looked up without a market when using client credentials.
+func getSpotifyShowMarket() string {
+	if spotifyConfig.Market != "" {
+		return spotifyConfig.Market
+	}
+	return "US"
+}
+
+func getSpotifyBestImage(images []spotifyImage) string {
+	best := ""
+	bestWidth := -1
+	for _, image := range images {
+		if image.Width > bestWidth {
+			best = image.URL
+			bestWidth = image.Width
+		}
+	}
+	return best
+}
+
+func getSpotifyColor(specific string) string {
+	spotifyColor := projectColor          // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		spotifyColor = generalConfig.DefaultColor
+	}
+	if spotifyConfig.DefaultColor != "" { // override with spotify if present
+		spotifyColor = spotifyConfig.DefaultColor
+	}
+	if specific != "" { // override with specific if present
+		spotifyColor = specific
+	}
+	return spotifyColor
+}
+
+// Sends a Spotify item to every destination that hasn't had it yet, returns false if any send failed.
+func sendSpotifyItem(l logInstructions, item refItem, destinations []feedDestination,
+	message discordwebhook.Message, module string, group int, feedName string) bool {
+	delivered := true
+	for _, destination := range destinations {
+		if refCheckSentToChannel(item.Ref, destination.Channel) {
+			if generalConfig.Debug2 {
+				log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- ALREADY SENT %s to %s", item.URL, destination.Channel))
+				l.ClearFlag()
+			}
+			continue
+		}
+		if suppressed, err := suppressDuplicate(item, destination.Channel, module); suppressed {
+			if err != nil {
+				log.Println(l.SetFlag(&lError).Log(
+					"Error listing %s as also reported on the original post: %s", feedName, err.Error()))
+				l.ClearFlag()
+			}
+			continue
+		}
+		destMessage := message
+		tags := getDestinationMentions(destination)
+		if tags != "" {
+			content := tags
+			if destMessage.Content != nil {
+				content += "\n" + *destMessage.Content
+			}
+			destMessage.Content = &content
+		}
+		// SEND
+		sendAttempts := 0
+	resend:
+		sendAttempts++
+		webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, item.URL)
+		if _, err := sendWebhookItem(destination.Channel, item, destMessage, module); err != nil {
+			if strings.Contains(err.Error(), "resource is being rate limited") && sendAttempts < 5 {
+				log.Println(l.SetFlag(&lError).Log(
+					"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+				l.ClearFlag()
+				time.Sleep(3 * time.Second)
+				goto resend
+			}
+			log.Println(l.SetFlag(&lError).Log(
+				"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+			l.ClearFlag()
+			delivered = false
+		} else {
+			markFeedNewItem(group, feedName)
+			if generalConfig.Debug2 {
+				log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", item.URL, destination.Channel))
+				l.ClearFlag()
+			}
+		}
+	}
+	return delivered
+}
+
+// Keeps a rate limit's Retry-After for the scheduler before passing the error on.
+func checkSpotifyError(group int, name string, err error) error {
+	var rateLimit spotifyRateLimitError
+	if errors.As(err, &rateLimit) {
+		hints := getFeedHints(group, name)
+		hints.RetryAfter = rateLimit.RetryAfter
+		setFeedHints(group, name, hints)
+	}
+	return err
+}
+
+//#endregion
+
+//#region Artists
+
+func getSpotifyArtistGroups(artist configModuleSpotifyArtist) []string {
+	var groups []string
+	if artist.IncludeAlbums == nil || *artist.IncludeAlbums {
+		groups = append(groups, "album")
+	}
+	if artist.IncludeSingles == nil || *artist.IncludeSingles {
+		groups = append(groups, "single")
+	}
+	if artist.IncludeAppearances == nil || *artist.IncludeAppearances {
+		groups = append(groups, "appears_on")
+	}
+	return groups
+}
+
+func getSpotifyAlbumTypeName(album spotifyAlbum) string {
+	if album.AlbumGroup == "appears_on" {
+		return "Appears On"
+	}
+	switch album.AlbumType {
+	case "single":
+		return "Single"
+	case "compilation":
+		return "Compilation"
+	}
+	return "Album"
+}
+
+const spotifyAlbumPageLimit = 20 // pages of 50 read per release group, the most a busy artist could need
+
+// Pages through each release group newest first, until the releases are older than the cursor. Groups are
+// fetched one at a time as Spotify lists them one after another, so one page could miss a whole group.
+func getSpotifyArtistAlbums(artistID string, groups []string, cursor string, proxy string) ([]spotifyAlbum, error) {
+	var albums []spotifyAlbum
+	for _, group := range groups {
+		for pages := 0; pages < spotifyAlbumPageLimit; pages++ {
+			query := url.Values{
+				"include_groups": {group},
+				"limit":          {"50"},
+				"offset":         {strconv.Itoa(pages * 50)},
+			}
+			if spotifyConfig.Market != "" {
+				query.Set("market", spotifyConfig.Market)
+			}
+			var page spotifyAlbumPage
+			if err := spotifyGet("/artists/"+artistID+"/albums", query, proxy, &page); err != nil {
+				return nil, fmt.Errorf("%s: %s", group, err)
+			}
+			albums = append(albums, page.Items...)
+			// The first run only needs the newest release, later runs stop once past the cursor
+			if page.Next == "" || len(page.Items) == 0 || cursor == "" ||
+				page.Items[len(page.Items)-1].ReleaseDate < cursor {
+				break
+			}
+		}
+	}
+	return albums, nil
+}
+
+func handleSpotifyArtist(artist configModuleSpotifyArtist) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleSpotifyArtist(%s): ", artist.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Spotify Artist \"%s\"", artist.Name))
+		l.ClearFlag()
+	}
+
+	artistID := parseSpotifyID(artist.ID, "artist")
+	proxy := resolveProxy(artist.Proxy, spotifyConfig.Proxy, spotifyConfig.ProxyPool)
+	groups := getSpotifyArtistGroups(artist)
+	if len(groups) == 0 {
+		return nil
+	}
+
+	// Artist Info
+	var info spotifyArtistInfo
+	if err := spotifyGet("/artists/"+artistID, nil, proxy, &info); err != nil {
+		return checkSpotifyError(feedSpotifyArtist, artist.Name, fmt.Errorf("[ID:%s] failed to fetch artist: %s", artistID, err))
+	}
+
+	// Releases
+	// The cursor is the newest release date seen, so the back catalogue isn't posted on the first run
+	// and releases Spotify adds with old dates don't show up as new.
+	cursor := feedCursorGet(feedSpotifyArtist, artist.Name)
+	albums, err := getSpotifyArtistAlbums(artistID, groups, cursor, proxy)
+	if err != nil {
+		return checkSpotifyError(feedSpotifyArtist, artist.Name, fmt.Errorf("[ID:%s] failed to fetch releases: %s", artistID, err))
+	}
+	sort.SliceStable(albums, func(i, j int) bool { // oldest to newest
+		return albums[i].ReleaseDate < albums[j].ReleaseDate
+	})
+
+	// Appearance Vars
+	username := info.Name
+	if artist.Username != "" {
+		username = artist.Username
+	}
+	avatar := getSpotifyBestImage(info.Images)
+	if artist.Avatar != "" {
+		avatar = artist.Avatar
+	}
+	embedColor, err := hexdec(getSpotifyColor(artist.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+
+	newest := cursor
+	undelivered := false // the cursor stops short of the first release that failed to send, so it's retried
+	var releaseTimes []time.Time
+	for _, album := range albums {
+		if ctxRoot.Err() != nil { // shutting down, rest will be picked up next launch
+			break
+		}
+		if released, err := time.Parse("2006-01-02", album.ReleaseDate); err == nil {
+			releaseTimes = append(releaseTimes, released)
+		}
+		link := album.ExternalURLs.Spotify
+		if link == "" {
+			link = "https://open.spotify.com/album/" + album.ID
+		}
+		item := refItem{
+			Ref:    normalizeURL(link),
+			URL:    normalizeURL(link),
+			Title:  album.Name,
+			Source: artist.Name,
+		}
+		if cursor == "" { // first run, just remember what's already out
+			for _, destination := range artist.Destinations {
+				refLogSent(item.Ref, destination.Channel, moduleNameSpotifyArtists)
+			}
+			if album.ReleaseDate > newest {
+				newest = album.ReleaseDate
+			}
+			continue
+		}
+		if album.ReleaseDate < cursor {
+			continue
+		}
+
+		var artistNames []string
+		for _, albumArtist := range album.Artists {
+			artistNames = append(artistNames, albumArtist.Name)
+		}
+		description := fmt.Sprintf("**%s** by %s", getSpotifyAlbumTypeName(album), strings.Join(artistNames, ", "))
+		releaseField := "Released"
+		tracksField := "Tracks"
+		tracks := fmt.Sprint(album.TotalTracks)
+		inline := true
+		cover := getSpotifyBestImage(album.Images)
+		footerText := "Spotify"
+		delivered := sendSpotifyItem(l, item, artist.Destinations, discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Content:   &link,
+			Embeds: &[]discordwebhook.Embed{{
+				Title:       &album.Name,
+				Url:         &link,
+				Description: &description,
+				Color:       &embedColor,
+				Fields: &[]discordwebhook.Field{
+					{Name: &releaseField, Value: &album.ReleaseDate, Inline: &inline},
+					{Name: &tracksField, Value: &tracks, Inline: &inline},
+				},
+				Image: &discordwebhook.Image{Url: &cover},
+				Footer: &discordwebhook.Footer{
+					Text:    &footerText,
+					IconUrl: &spotifyLogo,
+				},
+			}},
+		}, moduleNameSpotifyArtists, feedSpotifyArtist, artist.Name)
+		if !delivered {
+			undelivered = true
+		}
+		if !undelivered && album.ReleaseDate > newest {
+			newest = album.ReleaseDate
+		}
+	}
+	if cursor == "" && len(albums) == 0 { // nothing out yet, anything from today on is new
+		newest = time.Now().UTC().Format("2006-01-02")
+	}
+	if newest != cursor {
+		feedCursorSet(feedSpotifyArtist, artist.Name, newest)
+	}
+
+	hints := getFeedHints(feedSpotifyArtist, artist.Name)
+	hints.ItemTimes = releaseTimes
+	setFeedHints(feedSpotifyArtist, artist.Name, hints)
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Spotify Artist %s", artist.Name))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+func handleSpotifyArtistCmdOpts(config *configModuleSpotifyArtist,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-artist"]; ok {
+		config.ID = parseSpotifyID(opt.StringValue(), "artist")
+	}
+	if err := handleSpotifyCommonCmdOpts(&config.Destinations, &config.WaitMins, &config.Schedule,
+		&config.Username, &config.Avatar, &config.Color, optionMap, s, i); err != nil {
+		return err
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["include-albums"]; ok {
+		val := opt.BoolValue()
+		config.IncludeAlbums = &val
+	}
+	if opt, ok := optionMap["include-singles"]; ok {
+		val := opt.BoolValue()
+		config.IncludeSingles = &val
+	}
+	if opt, ok := optionMap["include-appearances"]; ok {
+		val := opt.BoolValue()
+		config.IncludeAppearances = &val
+	}
+	return nil
+}
+
+func newSpotifyArtistFeedThread(artist configModuleSpotifyArtist) feedThread {
+	thread := feedThread{
+		Group:       feedSpotifyArtist,
+		Name:        artist.Name,
+		Ref:         artist.ID,
+		Config:      artist,
+		WaitMins:    spotifyConfig.WaitMins,
+		Adaptive:    spotifyConfig.Adaptive,
+		MinWaitMins: spotifyConfig.MinWaitMins,
+		MaxWaitMins: spotifyConfig.MaxWaitMins,
+	}
+	setSpotifyFeedThreadOverrides(&thread, artist.WaitMins, artist.Adaptive, artist.MinWaitMins, artist.MaxWaitMins,
+		artist.Enabled, artist.Schedule)
+	return thread
+}
+
+func getSpotifyArtistConfigIndex(name string) int {
+	for k, feed := range spotifyConfig.Artists {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getSpotifyArtistConfig(name string) *configModuleSpotifyArtist {
+	i := getSpotifyArtistConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &spotifyConfig.Artists[i]
+	}
+}
+
+func existsSpotifyArtistConfig(name string) bool {
+	return getSpotifyArtistConfig(name) != nil
+}
+
+func updateSpotifyArtistConfig(name string, config configModuleSpotifyArtist) bool {
+	feedClone := spotifyConfig.Artists
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			spotifyConfig.Artists[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteSpotifyArtistConfig(name string) error {
+	index := getSpotifyArtistConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		spotifyConfig.Artists = append(spotifyConfig.Artists[:index], spotifyConfig.Artists[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedSpotifyArtist) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("spotify artist config does not exist")
+}
+
+func setSpotifyArtistConfigEnabled(name string, enabled bool) error {
+	config := getSpotifyArtistConfig(name)
+	if config == nil {
+		return errors.New("spotify artist config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedSpotifyArtist, *config)
+	return nil
+}
+
+//#endregion
+
+//#region Playlists
+
+const spotifyPlaylistMaxTracks = 10000 // stop paging past this, Spotify's own limit
+
+var (
+	spotifyUserNames      = make(map[string]string)
+	spotifyUserNamesMutex sync.Mutex
+)
+
+// Display name of a user, cached since playlist entries only carry the ID.
+func getSpotifyUserName(id string, proxy string) string {
+	spotifyUserNamesMutex.Lock()
+	name, cached := spotifyUserNames[id]
+	spotifyUserNamesMutex.Unlock()
+	if cached {
+		return name
+	}
+	var user spotifyUser
+	if err := spotifyGet("/users/"+url.PathEscape(id), nil, proxy, &user); err != nil {
+		return id
+	}
+	name = user.DisplayName
+	if name == "" {
+		name = id
+	}
+	spotifyUserNamesMutex.Lock()
+	spotifyUserNames[id] = name
+	spotifyUserNamesMutex.Unlock()
+	return name
+}
+
+// Every entry of a playlist in order, keyed the same way as the stored snapshot.
+func getSpotifyPlaylistTracks(playlistID string, proxy string) ([]dbPlaylistTrack, error) {
+	var tracks []dbPlaylistTrack
+	next := "/playlists/" + playlistID + "/tracks"
+	query := url.Values{"limit": {"100"}}
+	if spotifyConfig.Market != "" {
+		query.Set("market", spotifyConfig.Market)
+	}
+	for next != "" && len(tracks) < spotifyPlaylistMaxTracks {
+		var page spotifyPlaylistTrackPage
+		if err := spotifyGet(next, query, proxy, &page); err != nil {
+			return nil, err
+		}
+		query = nil // already in the next link
+		for _, entry := range page.Items {
+			if entry.Track == nil || entry.IsLocal {
+				continue
+			}
+			track := dbPlaylistTrack{
+				Playlist: playlistID,
+				URI:      entry.Track.URI,
+				Name:     entry.Track.Name,
+				Link:     entry.Track.ExternalURLs.Spotify,
+				Album:    entry.Track.Album.Name,
+				Image:    getSpotifyBestImage(entry.Track.Album.Images),
+				AddedAt:  entry.AddedAt,
+			}
+			var artistNames []string
+			for _, artist := range entry.Track.Artists {
+				artistNames = append(artistNames, artist.Name)
+			}
+			track.Artists = strings.Join(artistNames, ", ")
+			if entry.AddedBy != nil {
+				track.AddedBy = entry.AddedBy.ID
+			}
+			track.Key = track.URI + "|" + track.AddedAt.UTC().Format(time.RFC3339)
+			tracks = append(tracks, track)
+		}
+		next = page.Next
+	}
+	return tracks, nil
+}
+
+func handleSpotifyPlaylist(playlist configModuleSpotifyPlaylist) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleSpotifyPlaylist(%s): ", playlist.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Spotify Playlist \"%s\"", playlist.Name))
+		l.ClearFlag()
+	}
+
+	playlistID := parseSpotifyID(playlist.ID, "playlist")
+	proxy := resolveProxy(playlist.Proxy, spotifyConfig.Proxy, spotifyConfig.ProxyPool)
+
+	// Snapshot ID changes on every edit, so unchanged playlists cost one small request
+	var info spotifyPlaylist
+	if err := spotifyGet("/playlists/"+playlistID,
+		url.Values{"fields": {"id,name,snapshot_id,images,external_urls,owner"}}, proxy, &info); err != nil {
+		return checkSpotifyError(feedSpotifyPlaylist, playlist.Name, fmt.Errorf("[ID:%s] failed to fetch playlist: %s", playlistID, err))
+	}
+	cursor := feedCursorGet(feedSpotifyPlaylist, playlist.Name)
+	if info.SnapshotID == cursor {
+		if generalConfig.Debug {
+			log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Spotify Playlist %s unchanged", playlist.Name))
+			l.ClearFlag()
+		}
+		return nil
+	}
+
+	tracks, err := getSpotifyPlaylistTracks(playlistID, proxy)
+	if err != nil {
+		return checkSpotifyError(feedSpotifyPlaylist, playlist.Name, fmt.Errorf("[ID:%s] failed to fetch tracks: %s", playlistID, err))
+	}
+	previous := playlistTracksGet(playlist.Name, playlistID)
+	if cursor == "" { // first run, just remember what's already on it
+		if err := playlistTracksSet(playlist.Name, playlistID, tracks); err != nil {
+			return fmt.Errorf("[ID:%s] failed to save playlist snapshot: %s", playlistID, err)
+		}
+		feedCursorSet(feedSpotifyPlaylist, playlist.Name, info.SnapshotID)
+		return nil
+	}
+
+	// Diff
+	previousKeys := make(map[string]bool)
+	for _, track := range previous {
+		previousKeys[track.Key] = true
+	}
+	currentKeys := make(map[string]bool)
+	var added []dbPlaylistTrack
+	var addedTimes []time.Time
+	for _, track := range tracks {
+		currentKeys[track.Key] = true
+		addedTimes = append(addedTimes, track.AddedAt)
+		if !previousKeys[track.Key] {
+			added = append(added, track)
+		}
+	}
+	var removed []dbPlaylistTrack
+	if playlist.IncludeRemovals != nil && *playlist.IncludeRemovals {
+		for _, track := range previous {
+			if !currentKeys[track.Key] {
+				removed = append(removed, track)
+			}
+		}
+	}
+	sort.SliceStable(added, func(i, j int) bool {
+		return added[i].AddedAt.Before(added[j].AddedAt)
+	})
+
+	// Appearance Vars
+	username := info.Name
+	if playlist.Username != "" {
+		username = playlist.Username
+	}
+	avatar := getSpotifyBestImage(info.Images)
+	if playlist.Avatar != "" {
+		avatar = playlist.Avatar
+	}
+	embedColor, err := hexdec(getSpotifyColor(playlist.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+	playlistLink := info.ExternalURLs.Spotify
+	if playlistLink == "" {
+		playlistLink = "https://open.spotify.com/playlist/" + playlistID
+	}
+
+	post := func(track dbPlaylistTrack, change string, ref string) bool {
+		link := track.Link
+		if link == "" {
+			link = playlistLink
+		}
+		item := refItem{
+			Ref:    ref,
+			URL:    normalizeURL(link),
+			Title:  track.Name,
+			Source: playlist.Name,
+		}
+		description := fmt.Sprintf("**%s** %s", change, info.Name)
+		if track.AddedBy != "" && change == "Added to" {
+			description += " by " + getSpotifyUserName(track.AddedBy, proxy)
+		}
+		artistsField := "Artists"
+		albumField := "Album"
+		inline := true
+		footerText := "Spotify"
+		var fields []discordwebhook.Field
+		if track.Artists != "" {
+			fields = append(fields, discordwebhook.Field{Name: &artistsField, Value: &track.Artists, Inline: &inline})
+		}
+		if track.Album != "" {
+			fields = append(fields, discordwebhook.Field{Name: &albumField, Value: &track.Album, Inline: &inline})
+		}
+		return sendSpotifyItem(l, item, playlist.Destinations, discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Embeds: &[]discordwebhook.Embed{{
+				Title:       &track.Name,
+				Url:         &link,
+				Description: &description,
+				Color:       &embedColor,
+				Author: &discordwebhook.Author{
+					Name: &info.Name,
+					Url:  &playlistLink,
+				},
+				Fields:    &fields,
+				Thumbnail: &discordwebhook.Thumbnail{Url: &track.Image},
+				Footer: &discordwebhook.Footer{
+					Text:    &footerText,
+					IconUrl: &spotifyLogo,
+				},
+			}},
+		}, moduleNameSpotifyPlaylists, feedSpotifyPlaylist, playlist.Name)
+	}
+	// Refs include when it was added, so a track taken off and put back is posted again.
+	// Changes that didn't reach every destination are left out of the snapshot to be tried again next run.
+	unsent := make(map[string]bool)
+	for _, track := range added {
+		if ctxRoot.Err() != nil { // shutting down, snapshot isn't saved so it's picked up next launch
+			return nil
+		}
+		if !post(track, "Added to", playlistLink+"#"+track.Key) {
+			unsent[track.Key] = true
+		}
+	}
+	var unsentRemoved []dbPlaylistTrack
+	for _, track := range removed {
+		if ctxRoot.Err() != nil {
+			return nil
+		}
+		if !post(track, "Removed from", playlistLink+"#removed|"+track.Key) {
+			unsentRemoved = append(unsentRemoved, track)
+		}
+	}
+	snapshot := unsentRemoved
+	for _, track := range tracks {
+		if !unsent[track.Key] {
+			snapshot = append(snapshot, track)
+		}
+	}
+
+	if err := playlistTracksSet(playlist.Name, playlistID, snapshot); err != nil {
+		return fmt.Errorf("[ID:%s] failed to save playlist snapshot: %s", playlistID, err)
+	}
+	if len(unsent) == 0 && len(unsentRemoved) == 0 { // otherwise an unchanged snapshot ID would skip the retry
+		feedCursorSet(feedSpotifyPlaylist, playlist.Name, info.SnapshotID)
+	}
+
+	hints := getFeedHints(feedSpotifyPlaylist, playlist.Name)
+	hints.ItemTimes = addedTimes
+	setFeedHints(feedSpotifyPlaylist, playlist.Name, hints)
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Spotify Playlist %s, %d added, %d removed",
+			playlist.Name, len(added), len(removed)))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+func handleSpotifyPlaylistCmdOpts(config *configModuleSpotifyPlaylist,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-playlist"]; ok {
+		config.ID = parseSpotifyID(opt.StringValue(), "playlist")
+	}
+	if err := handleSpotifyCommonCmdOpts(&config.Destinations, &config.WaitMins, &config.Schedule,
+		&config.Username, &config.Avatar, &config.Color, optionMap, s, i); err != nil {
+		return err
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["include-removals"]; ok {
+		val := opt.BoolValue()
+		config.IncludeRemovals = &val
+	}
+	return nil
+}
+
+func newSpotifyPlaylistFeedThread(playlist configModuleSpotifyPlaylist) feedThread {
+	thread := feedThread{
+		Group:       feedSpotifyPlaylist,
+		Name:        playlist.Name,
+		Ref:         playlist.ID,
+		Config:      playlist,
+		WaitMins:    spotifyConfig.WaitMins,
+		Adaptive:    spotifyConfig.Adaptive,
+		MinWaitMins: spotifyConfig.MinWaitMins,
+		MaxWaitMins: spotifyConfig.MaxWaitMins,
+	}
+	setSpotifyFeedThreadOverrides(&thread, playlist.WaitMins, playlist.Adaptive, playlist.MinWaitMins, playlist.MaxWaitMins,
+		playlist.Enabled, playlist.Schedule)
+	return thread
+}
+
+func getSpotifyPlaylistConfigIndex(name string) int {
+	for k, feed := range spotifyConfig.Playlists {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getSpotifyPlaylistConfig(name string) *configModuleSpotifyPlaylist {
+	i := getSpotifyPlaylistConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &spotifyConfig.Playlists[i]
+	}
+}
+
+func existsSpotifyPlaylistConfig(name string) bool {
+	return getSpotifyPlaylistConfig(name) != nil
+}
+
+func updateSpotifyPlaylistConfig(name string, config configModuleSpotifyPlaylist) bool {
+	feedClone := spotifyConfig.Playlists
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			spotifyConfig.Playlists[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteSpotifyPlaylistConfig(name string) error {
+	index := getSpotifyPlaylistConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		spotifyConfig.Playlists = append(spotifyConfig.Playlists[:index], spotifyConfig.Playlists[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedSpotifyPlaylist) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("spotify playlist config does not exist")
+}
+
+func setSpotifyPlaylistConfigEnabled(name string, enabled bool) error {
+	config := getSpotifyPlaylistConfig(name)
+	if config == nil {
+		return errors.New("spotify playlist config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedSpotifyPlaylist, *config)
+	return nil
+}
+
+//#endregion
+
+//#region Podcasts
+
+const spotifyEpisodeDescriptionLength = 500
+
+func handleSpotifyPodcast(podcast configModuleSpotifyPodcast) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleSpotifyPodcast(%s): ", podcast.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Spotify Podcast \"%s\"", podcast.Name))
+		l.ClearFlag()
+	}
+
+	showID := parseSpotifyID(podcast.ID, "show")
+	proxy := resolveProxy(podcast.Proxy, spotifyConfig.Proxy, spotifyConfig.ProxyPool)
+	market := url.Values{"market": {getSpotifyShowMarket()}}
+
+	// Show Info
+	var show spotifyShow
+	if err := spotifyGet("/shows/"+showID, market, proxy, &show); err != nil {
+		return checkSpotifyError(feedSpotifyPodcast, podcast.Name, fmt.Errorf("[ID:%s] failed to fetch show: %s", showID, err))
+	}
+
+	// Episodes, newest first
+	query := url.Values{"market": market["market"], "limit": {"50"}}
+	var page spotifyEpisodePage
+	if err := spotifyGet("/shows/"+showID+"/episodes", query, proxy, &page); err != nil {
+		return checkSpotifyError(feedSpotifyPodcast, podcast.Name, fmt.Errorf("[ID:%s] failed to fetch episodes: %s", showID, err))
+	}
+	var episodes []spotifyEpisode
+	for _, episode := range page.Items {
+		if episode != nil {
+			episodes = append(episodes, *episode)
+		}
+	}
+	sort.SliceStable(episodes, func(i, j int) bool { // oldest to newest
+		return episodes[i].ReleaseDate < episodes[j].ReleaseDate
+	})
+
+	// Appearance Vars
+	username := show.Name
+	if podcast.Username != "" {
+		username = podcast.Username
+	}
+	avatar := getSpotifyBestImage(show.Images)
+	if podcast.Avatar != "" {
+		avatar = podcast.Avatar
+	}
+	embedColor, err := hexdec(getSpotifyColor(podcast.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+	descriptionLength := spotifyEpisodeDescriptionLength
+	if podcast.DescriptionLength != nil {
+		descriptionLength = *podcast.DescriptionLength
+	}
+	showLink := show.ExternalURLs.Spotify
+	if showLink == "" {
+		showLink = "https://open.spotify.com/show/" + showID
+	}
+
+	// Same as artists, the cursor is the newest release date so the back catalogue isn't posted on the first run
+	cursor := feedCursorGet(feedSpotifyPodcast, podcast.Name)
+	newest := cursor
+	undelivered := false // the cursor stops short of the first episode that failed to send, so it's retried
+	var releaseTimes []time.Time
+	for _, episode := range episodes {
+		if ctxRoot.Err() != nil { // shutting down, rest will be picked up next launch
+			break
+		}
+		if released, err := time.Parse("2006-01-02", episode.ReleaseDate); err == nil {
+			releaseTimes = append(releaseTimes, released)
+		}
+		link := episode.ExternalURLs.Spotify
+		if link == "" {
+			link = "https://open.spotify.com/episode/" + episode.ID
+		}
+		item := refItem{
+			Ref:    normalizeURL(link),
+			URL:    normalizeURL(link),
+			Title:  episode.Name,
+			Source: podcast.Name,
+		}
+		if cursor == "" { // first run, just remember what's already out
+			for _, destination := range podcast.Destinations {
+				refLogSent(item.Ref, destination.Channel, moduleNameSpotifyPodcasts)
+			}
+			if episode.ReleaseDate > newest {
+				newest = episode.ReleaseDate
+			}
+			continue
+		}
+		if episode.ReleaseDate < cursor {
+			continue
+		}
+
+		description := ""
+		if descriptionLength > 0 {
+			if episode.HTMLDescription != "" {
+				description = htmlToMarkdown(episode.HTMLDescription)
+			} else {
+				description = markdownEscaper.Replace(episode.Description)
+			}
+			description = truncateText(description, descriptionLength)
+		}
+		releaseField := "Released"
+		durationField := "Duration"
+		duration := shortenTime(durafmt.ParseShort(time.Duration(episode.DurationMs) * time.Millisecond).String())
+		inline := true
+		fields := []discordwebhook.Field{
+			{Name: &releaseField, Value: &episode.ReleaseDate, Inline: &inline},
+			{Name: &durationField, Value: &duration, Inline: &inline},
+		}
+		if episode.Explicit {
+			explicitField := "Explicit"
+			explicit := "Yes"
+			fields = append(fields, discordwebhook.Field{Name: &explicitField, Value: &explicit, Inline: &inline})
+		}
+		artwork := getSpotifyBestImage(episode.Images)
+		if artwork == "" {
+			artwork = getSpotifyBestImage(show.Images)
+		}
+		footerText := "Spotify"
+		delivered := sendSpotifyItem(l, item, podcast.Destinations, discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Embeds: &[]discordwebhook.Embed{{
+				Title:       &episode.Name,
+				Url:         &link,
+				Description: &description,
+				Color:       &embedColor,
+				Author: &discordwebhook.Author{
+					Name: &show.Name,
+					Url:  &showLink,
+				},
+				Fields:    &fields,
+				Thumbnail: &discordwebhook.Thumbnail{Url: &artwork},
+				Footer: &discordwebhook.Footer{
+					Text:    &footerText,
+					IconUrl: &spotifyLogo,
+				},
+			}},
+		}, moduleNameSpotifyPodcasts, feedSpotifyPodcast, podcast.Name)
+		if !delivered {
+			undelivered = true
+		}
+		if !undelivered && episode.ReleaseDate > newest {
+			newest = episode.ReleaseDate
+		}
+	}
+	if cursor == "" && len(episodes) == 0 { // nothing out yet, anything from today on is new
+		newest = time.Now().UTC().Format("2006-01-02")
+	}
+	if newest != cursor {
+		feedCursorSet(feedSpotifyPodcast, podcast.Name, newest)
+	}
+
+	hints := getFeedHints(feedSpotifyPodcast, podcast.Name)
+	hints.ItemTimes = releaseTimes
+	setFeedHints(feedSpotifyPodcast, podcast.Name, hints)
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Spotify Podcast %s", podcast.Name))
+		l.ClearFlag()
+	}
+
 	return nil
 }
+
+func handleSpotifyPodcastCmdOpts(config *configModuleSpotifyPodcast,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-show"]; ok {
+		config.ID = parseSpotifyID(opt.StringValue(), "show")
+	}
+	if err := handleSpotifyCommonCmdOpts(&config.Destinations, &config.WaitMins, &config.Schedule,
+		&config.Username, &config.Avatar, &config.Color, optionMap, s, i); err != nil {
+		return err
+	}
+	if opt, ok := optionMap["description-length"]; ok {
+		val := int(opt.IntValue())
+		config.DescriptionLength = &val
+	}
+	return nil
+}
+
+func newSpotifyPodcastFeedThread(podcast configModuleSpotifyPodcast) feedThread {
+	thread := feedThread{
+		Group:       feedSpotifyPodcast,
+		Name:        podcast.Name,
+		Ref:         podcast.ID,
+		Config:      podcast,
+		WaitMins:    spotifyConfig.WaitMins,
+		Adaptive:    spotifyConfig.Adaptive,
+		MinWaitMins: spotifyConfig.MinWaitMins,
+		MaxWaitMins: spotifyConfig.MaxWaitMins,
+	}
+	setSpotifyFeedThreadOverrides(&thread, podcast.WaitMins, podcast.Adaptive, podcast.MinWaitMins, podcast.MaxWaitMins,
+		podcast.Enabled, podcast.Schedule)
+	return thread
+}
+
+func getSpotifyPodcastConfigIndex(name string) int {
+	for k, feed := range spotifyConfig.Podcasts {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getSpotifyPodcastConfig(name string) *configModuleSpotifyPodcast {
+	i := getSpotifyPodcastConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &spotifyConfig.Podcasts[i]
+	}
+}
+
+func existsSpotifyPodcastConfig(name string) bool {
+	return getSpotifyPodcastConfig(name) != nil
+}
+
+func updateSpotifyPodcastConfig(name string, config configModuleSpotifyPodcast) bool {
+	feedClone := spotifyConfig.Podcasts
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			spotifyConfig.Podcasts[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteSpotifyPodcastConfig(name string) error {
+	index := getSpotifyPodcastConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		spotifyConfig.Podcasts = append(spotifyConfig.Podcasts[:index], spotifyConfig.Podcasts[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedSpotifyPodcast) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("spotify podcast config does not exist")
+}
+
+func setSpotifyPodcastConfigEnabled(name string, enabled bool) error {
+	config := getSpotifyPodcastConfig(name)
+	if config == nil {
+		return errors.New("spotify podcast config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedSpotifyPodcast, *config)
+	return nil
+}
+
+//#endregion
+
+//#region Shared
+
+// Options every Spotify feed type has, split out since they all share the same shape.
+func handleSpotifyCommonCmdOpts(destinations *[]feedDestination, waitMins **int, schedule *string,
+	username *string, avatar *string, embedColor *string,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			for key, destination := range *destinations {
+				if destination.Channel == i.ChannelID {
+					(*destinations)[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		*waitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		*schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		*username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		*avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		*embedColor = opt.StringValue()
+	}
+	return nil
+}
+
+func setSpotifyFeedThreadOverrides(thread *feedThread, waitMins *int, adaptive *bool,
+	minWaitMins *int, maxWaitMins *int, enabled *bool, schedule string) {
+	if waitMins != nil {
+		thread.WaitMins = *waitMins
+	}
+	if adaptive != nil {
+		thread.Adaptive = *adaptive
+	}
+	if minWaitMins != nil {
+		thread.MinWaitMins = *minWaitMins
+	}
+	if maxWaitMins != nil {
+		thread.MaxWaitMins = *maxWaitMins
+	}
+	if enabled != nil {
+		thread.Paused = !*enabled
+	}
+	if schedule != "" {
+		setFeedSchedule(thread, schedule)
+	} else {
+		setFeedSchedule(thread, spotifyConfig.Schedule)
+	}
+}
+
+//#endregion
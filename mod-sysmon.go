@@ -0,0 +1,536 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/hako/durafmt"
+)
+
+var (
+	pathConfigModuleSysmon = pathConfigModules + string(os.PathSeparator) + "sysmon.json"
+	sysmonConfig           configModuleSysmon
+
+	sysmonStateName = "system-monitor" // status message IDs are kept in the feed state table under feed0
+)
+
+type configModuleSysmon struct {
+	Enabled  bool `json:"enabled"`
+	WaitMins int  `json:"waitMins,omitempty"` // between samples, default 5
+
+	Destinations      []feedDestination `json:"destinations,omitempty"`      // status message, edited in place
+	AlertDestinations []feedDestination `json:"alertDestinations,omitempty"` // threshold alerts, admins if empty
+
+	Disks      []string `json:"disks,omitempty"`      // mount paths, the data folder's if empty
+	Interfaces []string `json:"interfaces,omitempty"` // network interfaces, all but loopback if empty
+
+	Thresholds        configModuleSysmonThresholds `json:"thresholds,omitempty"`
+	AlertCooldownMins int                          `json:"alertCooldownMins,omitempty"` // before repeating an ongoing alert, default 60
+
+	// APPEARANCE
+	Username   string `json:"username,omitempty"`
+	Avatar     string `json:"avatar,omitempty"`
+	Color      string `json:"color,omitempty"`
+	AlertColor string `json:"alertColor,omitempty"` // status embed color while an alert is active
+}
+
+// Percentages unless noted, 0 uses the default and negative disables.
+type configModuleSysmonThresholds struct {
+	CPU         float64 `json:"cpu,omitempty"`         // default 90
+	Memory      float64 `json:"memory,omitempty"`      // default 90
+	Swap        float64 `json:"swap,omitempty"`        // default off
+	Disk        float64 `json:"disk,omitempty"`        // default 90
+	LoadPerCore float64 `json:"loadPerCore,omitempty"` // 5 minute load divided by cores, default off
+	Goroutines  float64 `json:"goroutines,omitempty"`  // count, default off
+	Backlog     float64 `json:"backlog,omitempty"`     // overdue feeds, default off
+	DatabaseMB  float64 `json:"databaseMB,omitempty"`  // default off
+}
+
+func loadConfig_Module_Sysmon() error {
+	prefixHere := "loadConfig_Module_Sysmon(): "
+	// TODO: Creation prompts if missing
+
+	// LOAD JSON CONFIG
+	if _, err := os.Stat(pathConfigModuleSysmon); err != nil {
+		return fmt.Errorf("sysmon config file not found: %s", err)
+	} else {
+		configBytes, err := os.ReadFile(pathConfigModuleSysmon)
+		if err != nil {
+			return fmt.Errorf("failed to read sysmon config file: %s", err)
+		} else {
+			// Fix backslashes
+			configStr := string(configBytes)
+			configStr = strings.ReplaceAll(configStr, "\\", "\\\\")
+			for strings.Contains(configStr, "\\\\\\") {
+				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
+			}
+			// Parse
+			if err = json.Unmarshal([]byte(configStr), &sysmonConfig); err != nil {
+				return fmt.Errorf("failed to parse sysmon config file: %s", err)
+			}
+			// Output?
+			if generalConfig.OutputSettings {
+				s, err := json.MarshalIndent(sysmonConfig, "", "\t")
+				if err != nil {
+					log.Println(color.HiRedString(prefixHere+"failed to output...\t%s", err))
+				} else {
+					log.Println(color.HiYellowString(prefixHere+"\n%s", color.YellowString(string(s))))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//#region Sampling
+
+type sysmonDisk struct {
+	Path  string
+	Total uint64
+	Used  uint64
+}
+
+type sysmonSample struct {
+	Time     time.Time
+	HasHost  bool // host stats could be read, see sampleHostStats()
+	HasRates bool // there was a previous sample to compare against
+
+	CPUTotal   uint64
+	CPUIdle    uint64
+	CPUPercent float64
+	Load       [3]float64
+	HostUptime time.Duration
+
+	MemTotal  uint64
+	MemUsed   uint64
+	SwapTotal uint64
+	SwapUsed  uint64
+
+	Disks []sysmonDisk
+
+	NetRx     uint64
+	NetTx     uint64
+	NetRxRate float64 // bytes per second
+	NetTxRate float64
+
+	Processes int
+	BotRSS    uint64
+	BotFDs    int
+
+	// Bot, sampled on every platform
+	Goroutines   int
+	HeapAlloc    uint64
+	DatabaseSize int64
+	Feeds        sysmonFeedCounts
+}
+
+type sysmonFeedCounts struct {
+	Total   int
+	Running int
+	Overdue int // should have started by now but haven't
+	Failing int
+	Paused  int
+}
+
+func getSysmonDisks() []string {
+	if len(sysmonConfig.Disks) > 0 {
+		return sysmonConfig.Disks
+	}
+	return []string{pathData}
+}
+
+func checkSysmonInterface(name string) bool {
+	if len(sysmonConfig.Interfaces) == 0 {
+		return name != "lo"
+	}
+	for _, allowed := range sysmonConfig.Interfaces {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func getSysmonFeedCounts() sysmonFeedCounts {
+	var counts sysmonFeedCounts
+	now := time.Now()
+	for _, feed := range getFeedsSnapshot() {
+		if feed.Deleted {
+			continue
+		}
+		counts.Total++
+		switch {
+		case feed.Paused:
+			counts.Paused++
+		case feed.Running:
+			counts.Running++
+		case !feed.NextRun.IsZero() && now.Sub(feed.NextRun) > time.Minute:
+			counts.Overdue++
+		}
+		if feed.Failures > 0 {
+			counts.Failing++
+		}
+	}
+	return counts
+}
+
+func getDatabaseSize() int64 {
+	var size int64
+	for _, path := range []string{pathDatabaseRefs, pathDatabaseRefs + "-wal"} {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+func sampleSystem(previous *sysmonSample) (sysmonSample, error) {
+	sample, err := sampleHostStats(previous)
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	sample.Goroutines = runtime.NumGoroutine()
+	sample.HeapAlloc = memStats.HeapAlloc
+	sample.DatabaseSize = getDatabaseSize()
+	sample.Feeds = getSysmonFeedCounts()
+	return sample, err
+}
+
+func getPercent(used uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(used) / float64(total)
+}
+
+//#endregion
+
+//#region Alerts
+
+type sysmonCheck struct {
+	Key       string
+	Label     string
+	Value     float64
+	Threshold float64
+	Unit      string
+}
+
+var (
+	sysmonAlerts = make(map[string]time.Time) // active alert keys, when last sent
+)
+
+// Threshold from config, 0 uses the default and negative disables.
+func getSysmonThreshold(value float64, def float64) float64 {
+	if value == 0 {
+		return def
+	}
+	return value
+}
+
+func getSysmonChecks(sample sysmonSample) []sysmonCheck {
+	thresholds := sysmonConfig.Thresholds
+	var checks []sysmonCheck
+	if sample.HasHost {
+		if sample.HasRates {
+			checks = append(checks, sysmonCheck{"cpu", "CPU", sample.CPUPercent,
+				getSysmonThreshold(thresholds.CPU, 90), "%"})
+		}
+		checks = append(checks, sysmonCheck{"memory", "Memory", getPercent(sample.MemUsed, sample.MemTotal),
+			getSysmonThreshold(thresholds.Memory, 90), "%"})
+		if sample.SwapTotal > 0 {
+			checks = append(checks, sysmonCheck{"swap", "Swap", getPercent(sample.SwapUsed, sample.SwapTotal),
+				getSysmonThreshold(thresholds.Swap, -1), "%"})
+		}
+		checks = append(checks, sysmonCheck{"load", "Load per core", sample.Load[1] / float64(runtime.NumCPU()),
+			getSysmonThreshold(thresholds.LoadPerCore, -1), ""})
+		for _, disk := range sample.Disks {
+			checks = append(checks, sysmonCheck{"disk:" + disk.Path, fmt.Sprintf("Disk `%s`", disk.Path),
+				getPercent(disk.Used, disk.Total), getSysmonThreshold(thresholds.Disk, 90), "%"})
+		}
+	}
+	checks = append(checks,
+		sysmonCheck{"goroutines", "Goroutines", float64(sample.Goroutines),
+			getSysmonThreshold(thresholds.Goroutines, -1), ""},
+		sysmonCheck{"backlog", "Overdue feeds", float64(sample.Feeds.Overdue),
+			getSysmonThreshold(thresholds.Backlog, -1), ""},
+		sysmonCheck{"database", "Database", float64(sample.DatabaseSize) / 1024 / 1024,
+			getSysmonThreshold(thresholds.DatabaseMB, -1), " MB"},
+	)
+	return checks
+}
+
+func formatSysmonValue(value float64, unit string) string {
+	if unit == "" && value == float64(int64(value)) {
+		return fmt.Sprint(int64(value))
+	}
+	return fmt.Sprintf("%.1f%s", value, unit)
+}
+
+// Alerts once a check goes over its threshold, again after the cooldown while it stays over, and once it recovers.
+func checkSysmonAlerts(sample sysmonSample) {
+	cooldown := 60 * time.Minute
+	if sysmonConfig.AlertCooldownMins > 0 {
+		cooldown = time.Duration(sysmonConfig.AlertCooldownMins) * time.Minute
+	}
+	for _, check := range getSysmonChecks(sample) {
+		if check.Threshold < 0 {
+			delete(sysmonAlerts, check.Key)
+			continue
+		}
+		lastSent, active := sysmonAlerts[check.Key]
+		value := formatSysmonValue(check.Value, check.Unit)
+		threshold := formatSysmonValue(check.Threshold, check.Unit)
+		if check.Value >= check.Threshold {
+			if active && time.Since(lastSent) < cooldown {
+				continue
+			}
+			verb := "is at"
+			if active {
+				verb = "is still at"
+			}
+			sendSysmonAlert(fmt.Sprintf("⚠️ **%s %s %s** on the bot's host, threshold %s.",
+				check.Label, verb, value, threshold))
+			sysmonAlerts[check.Key] = time.Now()
+		} else if active {
+			sendSysmonAlert(fmt.Sprintf("✅ %s is back to %s, threshold %s.", check.Label, value, threshold))
+			delete(sysmonAlerts, check.Key)
+		}
+	}
+}
+
+func sendSysmonAlert(message string) {
+	l := logInstructions{
+		Location: "sendSysmonAlert",
+		Task:     "",
+		Inline:   false,
+		Color:    color.HiRedString,
+	}
+	log.Println(l.SetFlag(&lWarning).Log(message))
+	l.ClearFlag()
+	if len(sysmonConfig.AlertDestinations) == 0 {
+		notifyAdmins(message)
+		return
+	}
+	for _, destination := range sysmonConfig.AlertDestinations {
+		content := message
+		if mentions := getDestinationMentions(destination); mentions != "" {
+			content = mentions + "\n" + content
+		}
+		if _, err := executeSysmonWebhook(destination.Channel, &discordgo.WebhookParams{Content: content}); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Error sending alert to %s: %s", destination.Channel, err))
+			l.ClearFlag()
+		}
+	}
+}
+
+//#endregion
+
+//#region Status
+
+func getSysmonColor(alerting bool) int {
+	specific := sysmonConfig.Color
+	if alerting {
+		specific = "#E74C3C"
+		if sysmonConfig.AlertColor != "" {
+			specific = sysmonConfig.AlertColor
+		}
+	}
+	sysmonColor := projectColor           // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		sysmonColor = generalConfig.DefaultColor
+	}
+	if specific != "" { // override with specific if present
+		sysmonColor = specific
+	}
+	embedColor, _ := hexdec(sysmonColor)
+	colorInt, _ := strconv.Atoi(embedColor)
+	return colorInt
+}
+
+func buildSysmonEmbed(sample sysmonSample, hostErr error) *discordgo.MessageEmbed {
+	var fields []*discordgo.MessageEmbedField
+	addField := func(name string, value string) {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: name, Value: value, Inline: true})
+	}
+	if sample.HasHost {
+		if sample.HasRates {
+			addField("CPU", fmt.Sprintf("%.1f%%", sample.CPUPercent))
+		} else {
+			addField("CPU", "—")
+		}
+		addField("Load", fmt.Sprintf("%.2f · %.2f · %.2f\n_%d core%s_",
+			sample.Load[0], sample.Load[1], sample.Load[2], runtime.NumCPU(), ssuff(runtime.NumCPU())))
+		addField("Memory", fmt.Sprintf("%s / %s\n_%.1f%%_", humanize.IBytes(sample.MemUsed),
+			humanize.IBytes(sample.MemTotal), getPercent(sample.MemUsed, sample.MemTotal)))
+		if sample.SwapTotal > 0 {
+			addField("Swap", fmt.Sprintf("%s / %s\n_%.1f%%_", humanize.IBytes(sample.SwapUsed),
+				humanize.IBytes(sample.SwapTotal), getPercent(sample.SwapUsed, sample.SwapTotal)))
+		}
+		for _, disk := range sample.Disks {
+			addField(fmt.Sprintf("Disk %s", disk.Path), fmt.Sprintf("%s / %s\n_%.1f%%_", humanize.IBytes(disk.Used),
+				humanize.IBytes(disk.Total), getPercent(disk.Used, disk.Total)))
+		}
+		if sample.HasRates {
+			addField("Network", fmt.Sprintf("↓ %s/s\n↑ %s/s",
+				humanize.Bytes(uint64(sample.NetRxRate)), humanize.Bytes(uint64(sample.NetTxRate))))
+		}
+		addField("Processes", fmt.Sprint(sample.Processes))
+		addField("Host Uptime", shortenTime(durafmt.ParseShort(sample.HostUptime).String()))
+	}
+	bot := fmt.Sprintf("%d goroutines\n%s heap", sample.Goroutines, humanize.IBytes(sample.HeapAlloc))
+	if sample.BotRSS > 0 {
+		bot += fmt.Sprintf(", %s rss", humanize.IBytes(sample.BotRSS))
+	}
+	if sample.BotFDs > 0 {
+		bot += fmt.Sprintf("\n%d open files", sample.BotFDs)
+	}
+	addField("Bot", bot)
+	addField("Bot Uptime", shortenTime(durafmt.ParseShort(uptime()).String()))
+	addField("Database", fmt.Sprintf("%s\n_%d refs_", humanize.Bytes(uint64(sample.DatabaseSize)), refCount()))
+	feedsValue := fmt.Sprintf("%d total, %d running\n%d overdue, %d failing, %d paused",
+		sample.Feeds.Total, sample.Feeds.Running, sample.Feeds.Overdue, sample.Feeds.Failing, sample.Feeds.Paused)
+	fields = append(fields, &discordgo.MessageEmbedField{Name: "Feeds", Value: feedsValue})
+
+	description := ""
+	if hostErr != nil {
+		description = fmt.Sprintf("_Host stats unavailable: %s_", hostErr)
+	}
+	if len(sysmonAlerts) > 0 {
+		var active []string
+		for _, check := range getSysmonChecks(sample) {
+			if _, alerting := sysmonAlerts[check.Key]; alerting {
+				active = append(active, fmt.Sprintf("⚠️ %s at %s", check.Label, formatSysmonValue(check.Value, check.Unit)))
+			}
+		}
+		description = strings.TrimSpace(strings.Join(active, "\n") + "\n" + description)
+	}
+	hostname, _ := os.Hostname()
+	title := "System Status"
+	if hostname != "" {
+		title += " · " + hostname
+	}
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       getSysmonColor(len(sysmonAlerts) > 0),
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s · updates every %s", projectName,
+				shortenTime(durafmt.ParseShort(getSysmonInterval()).String())),
+		},
+		Timestamp: sample.Time.Format(time.RFC3339),
+	}
+}
+
+func executeSysmonWebhook(channel string, params *discordgo.WebhookParams) (*discordgo.Message, error) {
+	webhook, err := getWebhookForChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	params.Username = "System Monitor"
+	if sysmonConfig.Username != "" {
+		params.Username = sysmonConfig.Username
+	}
+	params.AvatarURL = sysmonConfig.Avatar
+	return discord.WebhookExecute(webhook.ID, webhook.Token, true, params)
+}
+
+// Status message ID per channel, kept across restarts so the same message keeps being edited.
+func getSysmonMessages() map[string]string {
+	messages := make(map[string]string)
+	if cursor := feedCursorGet(feed0, sysmonStateName); cursor != "" {
+		json.Unmarshal([]byte(cursor), &messages)
+	}
+	return messages
+}
+
+func setSysmonMessages(messages map[string]string) {
+	if cursorJson, err := json.Marshal(messages); err == nil {
+		feedCursorSet(feed0, sysmonStateName, string(cursorJson))
+	}
+}
+
+// Edits the status message in each destination, posting a new one if it was deleted.
+func updateSysmonStatus(embed *discordgo.MessageEmbed) {
+	l := logInstructions{
+		Location: "updateSysmonStatus",
+		Task:     "",
+		Inline:   false,
+		Color:    color.HiRedString,
+	}
+	messages := getSysmonMessages()
+	changed := false
+	embeds := []*discordgo.MessageEmbed{embed}
+	for _, destination := range sysmonConfig.Destinations {
+		if messageID, exists := messages[destination.Channel]; exists {
+			err := editWebhookMessage(destination.Channel, messageID, &discordgo.WebhookEdit{Embeds: &embeds})
+			if err == nil {
+				continue
+			}
+			var restErr *discordgo.RESTError
+			if !errors.As(err, &restErr) || restErr.Response == nil || restErr.Response.StatusCode != http.StatusNotFound {
+				log.Println(l.SetFlag(&lError).Log("Error editing status in %s: %s", destination.Channel, err))
+				l.ClearFlag()
+				continue
+			}
+		}
+		message, err := executeSysmonWebhook(destination.Channel, &discordgo.WebhookParams{Embeds: embeds})
+		if err != nil {
+			log.Println(l.SetFlag(&lError).Log("Error posting status in %s: %s", destination.Channel, err))
+			l.ClearFlag()
+			continue
+		}
+		messages[destination.Channel] = message.ID
+		changed = true
+	}
+	if changed {
+		setSysmonMessages(messages)
+	}
+}
+
+func getSysmonInterval() time.Duration {
+	if sysmonConfig.WaitMins > 0 {
+		return time.Duration(sysmonConfig.WaitMins) * time.Minute
+	}
+	return 5 * time.Minute
+}
+
+// Samples the host and the bot on an interval, keeping the status messages current and alerting on thresholds.
+func runSystemMonitor() {
+	if !sysmonConfig.Enabled {
+		return
+	}
+	// Baseline for the rates, the first report follows shortly after
+	previous, _ := sampleSystem(nil)
+	timer := time.NewTimer(5 * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctxSchedule.Done():
+			return
+		case <-timer.C:
+		}
+		sample, err := sampleSystem(&previous)
+		checkSysmonAlerts(sample)
+		if len(sysmonConfig.Destinations) > 0 {
+			updateSysmonStatus(buildSysmonEmbed(sample, err))
+		}
+		if err == nil {
+			previous = sample
+		}
+		timer.Reset(getSysmonInterval())
+	}
+}
+
+//#endregion
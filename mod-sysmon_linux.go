@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Reads the host's counters from /proc, rates (cpu, network) are taken against the previous sample.
+func sampleHostStats(previous *sysmonSample) (sysmonSample, error) {
+	sample := sysmonSample{Time: time.Now()}
+
+	// CPU
+	stat, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return sample, err
+	}
+	for _, line := range strings.Split(string(stat), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		for k, field := range fields[1:] {
+			if k >= 8 { // guest time is already counted in user
+				break
+			}
+			value, _ := strconv.ParseUint(field, 10, 64)
+			sample.CPUTotal += value
+			if k == 3 || k == 4 { // idle, iowait
+				sample.CPUIdle += value
+			}
+		}
+		break
+	}
+	if previous != nil && sample.CPUTotal > previous.CPUTotal {
+		total := float64(sample.CPUTotal - previous.CPUTotal)
+		idle := float64(sample.CPUIdle - previous.CPUIdle)
+		sample.CPUPercent = 100 * (total - idle) / total
+		sample.HasRates = true
+	}
+
+	// Memory
+	meminfo, err := readProcKeyValues("/proc/meminfo")
+	if err != nil {
+		return sample, err
+	}
+	sample.MemTotal = meminfo["MemTotal"] * 1024
+	sample.MemUsed = (meminfo["MemTotal"] - meminfo["MemAvailable"]) * 1024
+	sample.SwapTotal = meminfo["SwapTotal"] * 1024
+	sample.SwapUsed = (meminfo["SwapTotal"] - meminfo["SwapFree"]) * 1024
+
+	// Load
+	loadavg, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return sample, err
+	}
+	if fields := strings.Fields(string(loadavg)); len(fields) >= 3 {
+		for k := 0; k < 3; k++ {
+			sample.Load[k], _ = strconv.ParseFloat(fields[k], 64)
+		}
+	}
+	if uptime, err := os.ReadFile("/proc/uptime"); err == nil {
+		if fields := strings.Fields(string(uptime)); len(fields) > 0 {
+			secs, _ := strconv.ParseFloat(fields[0], 64)
+			sample.HostUptime = time.Duration(secs * float64(time.Second))
+		}
+	}
+
+	// Disks
+	for _, path := range getSysmonDisks() {
+		var fs syscall.Statfs_t
+		if err := syscall.Statfs(path, &fs); err != nil {
+			continue
+		}
+		size := fs.Blocks * uint64(fs.Bsize)
+		free := fs.Bavail * uint64(fs.Bsize)
+		used := size - fs.Bfree*uint64(fs.Bsize)
+		sample.Disks = append(sample.Disks, sysmonDisk{Path: path, Total: used + free, Used: used})
+	}
+
+	// Network
+	if netdev, err := os.ReadFile("/proc/net/dev"); err == nil {
+		for _, line := range strings.Split(string(netdev), "\n") {
+			name, counters, found := strings.Cut(line, ":")
+			if !found {
+				continue
+			}
+			name = strings.TrimSpace(name)
+			if !checkSysmonInterface(name) {
+				continue
+			}
+			fields := strings.Fields(counters)
+			if len(fields) < 9 {
+				continue
+			}
+			rx, _ := strconv.ParseUint(fields[0], 10, 64)
+			tx, _ := strconv.ParseUint(fields[8], 10, 64)
+			sample.NetRx += rx
+			sample.NetTx += tx
+		}
+		if previous != nil && sample.NetRx >= previous.NetRx && sample.NetTx >= previous.NetTx {
+			secs := sample.Time.Sub(previous.Time).Seconds()
+			if secs > 0 {
+				sample.NetRxRate = float64(sample.NetRx-previous.NetRx) / secs
+				sample.NetTxRate = float64(sample.NetTx-previous.NetTx) / secs
+			}
+		}
+	}
+
+	// Processes
+	if entries, err := os.ReadDir("/proc"); err == nil {
+		for _, entry := range entries {
+			if _, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+				sample.Processes++
+			}
+		}
+	}
+	if status, err := readProcKeyValues("/proc/self/status"); err == nil {
+		sample.BotRSS = status["VmRSS"] * 1024
+	}
+	if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+		sample.BotFDs = len(fds)
+	}
+
+	sample.HasHost = true
+	return sample, nil
+}
+
+// Parses "Key: 123 kB" lines, values are left in the file's units.
+func readProcKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		if number, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			values[key] = number
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.New("nothing to read in " + path)
+	}
+	return values, scanner.Err()
+}
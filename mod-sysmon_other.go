@@ -0,0 +1,13 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// Host stats are read from /proc, only the bot's own stats are reported elsewhere.
+func sampleHostStats(previous *sysmonSample) (sysmonSample, error) {
+	return sysmonSample{Time: time.Now()}, errors.New("host stats are only supported on linux")
+}
@@ -0,0 +1,1169 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/fatih/color"
+	"github.com/gtuk/discordwebhook"
+	"github.com/hako/durafmt"
+)
+
+var (
+	pathConfigModuleTwitch = pathConfigModules + string(os.PathSeparator) + "twitch.json"
+	twitchConfig           configModuleTwitch
+
+	moduleNameTwitchLive = "twitch-live"
+
+	twitchLogo = "https://upload.wikimedia.org/wikipedia/commons/thumb/d/d3/Twitch_Glitch_Logo_Purple.svg/240px-Twitch_Glitch_Logo_Purple.svg.png"
+)
+
+var (
+	twitchClientID     string
+	twitchClientSecret string
+)
+
+type configModuleTwitch struct {
+	WaitMins int `json:"waitMins,omitempty"`
+
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on stream activity instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
+	DefaultColor string `json:"defaultColor,omitempty"`
+
+	Channels []configModuleTwitchChannel `json:"channels"`
+
+	ChatAddress string                   `json:"chatAddress,omitempty"` // "ircs://host:port" or "irc://host:port", default Twitch's TLS server
+	Chat        []configModuleTwitchChat `json:"chat,omitempty"`
+}
+
+type configModuleTwitchChannel struct {
+	// MAIN
+	Name         string            `json:"name"`
+	Login        string            `json:"login"` // channel name as in twitch.tv/<login>
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	EditOnEnd *bool `json:"editOnEnd,omitempty"` // default true, edit the post with duration & peak viewers when the stream ends
+}
+
+type configModuleTwitchChat struct {
+	// MAIN
+	Name         string            `json:"name"`
+	Channel      string            `json:"channel"` // channel name as in twitch.tv/<channel>
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // not joined if false
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES, a message is forwarded if it matches any of these
+	Keywords          []string `json:"keywords,omitempty"`          // case insensitive
+	Users             []string `json:"users,omitempty"`             // logins to forward everything from
+	IncludeModerators *bool    `json:"includeModerators,omitempty"` // default false, includes the broadcaster
+	IncludeBits       *bool    `json:"includeBits,omitempty"`       // default false, cheers
+	IncludeSubs       *bool    `json:"includeSubs,omitempty"`       // default false, subs, resubs & gifts
+
+	RateLimit *int `json:"rateLimit,omitempty"` // messages forwarded per minute, default 10, extras are dropped
+	DedupMins *int `json:"dedupMins,omitempty"` // drop the same text from the same user within this window, default 5
+}
+
+func loadConfig_Module_Twitch() error {
+	prefixHere := "loadConfig_Module_Twitch(): "
+	// TODO: Creation prompts if missing
+
+	// LOAD JSON CONFIG
+	if _, err := os.Stat(pathConfigModuleTwitch); err != nil {
+		return fmt.Errorf("twitch config file not found: %s", err)
+	} else {
+		configBytes, err := os.ReadFile(pathConfigModuleTwitch)
+		if err != nil {
+			return fmt.Errorf("failed to read twitch config file: %s", err)
+		} else {
+			// Fix backslashes
+			configStr := string(configBytes)
+			configStr = strings.ReplaceAll(configStr, "\\", "\\\\")
+			for strings.Contains(configStr, "\\\\\\") {
+				configStr = strings.ReplaceAll(configStr, "\\\\\\", "\\\\")
+			}
+			// Parse
+			if err = json.Unmarshal([]byte(configStr), &twitchConfig); err != nil {
+				return fmt.Errorf("failed to parse twitch config file: %s", err)
+			}
+			if err = checkProxySettings(twitchConfig.Proxy, twitchConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid twitch proxy settings: %s", err)
+			}
+			// Output?
+			if generalConfig.OutputSettings {
+				s, err := json.MarshalIndent(twitchConfig, "", "\t")
+				if err != nil {
+					log.Println(color.HiRedString(prefixHere+"failed to output...\t%s", err))
+				} else {
+					log.Println(color.HiYellowString(prefixHere+"\n%s", color.YellowString(string(s))))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//#region API
+
+const twitchAPI = "https://api.twitch.tv/helix"
+
+var (
+	twitchToken        string
+	twitchTokenExpires time.Time
+	twitchTokenMutex   sync.Mutex
+)
+
+type twitchUser struct {
+	ID              string `json:"id"`
+	Login           string `json:"login"`
+	DisplayName     string `json:"display_name"`
+	ProfileImageURL string `json:"profile_image_url"`
+	OfflineImageURL string `json:"offline_image_url"`
+}
+
+type twitchStream struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	UserLogin    string    `json:"user_login"`
+	UserName     string    `json:"user_name"`
+	GameName     string    `json:"game_name"`
+	Type         string    `json:"type"` // "live", or "" on error
+	Title        string    `json:"title"`
+	ViewerCount  int       `json:"viewer_count"`
+	StartedAt    time.Time `json:"started_at"`
+	ThumbnailURL string    `json:"thumbnail_url"` // with {width} and {height} placeholders
+}
+
+// Returned when Helix runs out of points, so the feed can wait until the bucket refills.
+type twitchRateLimitError struct {
+	RetryAfter time.Time
+}
+
+func (e twitchRateLimitError) Error() string {
+	return "rate limited by twitch, retry " + humanizeTimeOrNever(e.RetryAfter)
+}
+
+// App access token, refreshed a minute before it expires.
+func getTwitchToken(client *http.Client) (string, error) {
+	twitchTokenMutex.Lock()
+	defer twitchTokenMutex.Unlock()
+	if twitchToken != "" && time.Now().Before(twitchTokenExpires) {
+		return twitchToken, nil
+	}
+	if twitchClientID == "" || twitchClientSecret == "" {
+		return "", errors.New("twitch credentials are missing from credentials.ini")
+	}
+
+	form := url.Values{
+		"client_id":     {twitchClientID},
+		"client_secret": {twitchClientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodPost,
+		"https://id.twitch.tv/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("twitch token request failed (%s): %s", resp.Status, body)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	twitchToken = token.AccessToken
+	twitchTokenExpires = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return twitchToken, nil
+}
+
+// GET against Helix, decoding the response into v.
+func twitchGet(path string, query url.Values, proxy string, v interface{}) error {
+	client, err := getProxyClient(proxy, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	token, err := getTwitchToken(client)
+	if err != nil {
+		return err
+	}
+
+	link := twitchAPI + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Client-Id", twitchClientID)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxRoot.Err() == nil {
+			markProxyFailed(proxy)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retry := time.Now().Add(time.Minute)
+		if reset, err := strconv.ParseInt(resp.Header.Get("Ratelimit-Reset"), 10, 64); err == nil {
+			retry = time.Unix(reset, 0)
+		}
+		return twitchRateLimitError{RetryAfter: retry}
+	case resp.StatusCode == http.StatusUnauthorized: // token revoked early, fetch a new one next time
+		twitchTokenMutex.Lock()
+		twitchToken = ""
+		twitchTokenMutex.Unlock()
+		return errors.New("twitch token was rejected")
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("twitch request failed (%s): %s", resp.Status, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func getTwitchUser(login string, proxy string) (*twitchUser, error) {
+	var result struct {
+		Data []twitchUser `json:"data"`
+	}
+	if err := twitchGet("/users", url.Values{"login": {login}}, proxy, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return nil, fmt.Errorf("no twitch channel named \"%s\"", login)
+	}
+	return &result.Data[0], nil
+}
+
+// The channel's stream, nil if it's offline.
+func getTwitchStream(login string, proxy string) (*twitchStream, error) {
+	var result struct {
+		Data []twitchStream `json:"data"`
+	}
+	if err := twitchGet("/streams", url.Values{"user_login": {login}}, proxy, &result); err != nil {
+		return nil, err
+	}
+	for _, stream := range result.Data {
+		if stream.Type == "live" {
+			return &stream, nil
+		}
+	}
+	return nil, nil
+}
+
+// Keeps a rate limit's reset time for the scheduler before passing the error on.
+func checkTwitchError(group int, name string, err error) error {
+	var rateLimit twitchRateLimitError
+	if errors.As(err, &rateLimit) {
+		hints := getFeedHints(group, name)
+		hints.RetryAfter = rateLimit.RetryAfter
+		setFeedHints(group, name, hints)
+	}
+	return err
+}
+
+// Login from a channel name, @name or twitch.tv link.
+func parseTwitchLogin(input string) string {
+	input = strings.TrimSpace(input)
+	if u, err := url.Parse(input); err == nil && strings.HasSuffix(u.Hostname(), "twitch.tv") {
+		input = strings.Split(strings.Trim(u.Path, "/"), "/")[0]
+	}
+	return strings.ToLower(strings.TrimPrefix(input, "@"))
+}
+
+func getTwitchColor(specific string) string {
+	twitchColor := projectColor           // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		twitchColor = generalConfig.DefaultColor
+	}
+	if twitchConfig.DefaultColor != "" { // override with twitch if present
+		twitchColor = twitchConfig.DefaultColor
+	}
+	if specific != "" { // override with specific if present
+		twitchColor = specific
+	}
+	return twitchColor
+}
+
+//#endregion
+
+//#region Live
+
+// What's known about a channel's current stream, kept in the feed cursor so a restart mid-stream
+// neither reposts it nor forgets to edit the post when it ends.
+type twitchLiveState struct {
+	StreamID    string            `json:"streamId"`
+	StartedAt   time.Time         `json:"startedAt"`
+	LastSeen    time.Time         `json:"lastSeen"`
+	PeakViewers int               `json:"peakViewers"`
+	Title       string            `json:"title"`
+	Game        string            `json:"game"`
+	Thumbnail   string            `json:"thumbnail"`
+	Messages    map[string]string `json:"messages"` // discord channel to message ID
+}
+
+func getTwitchLiveState(name string) twitchLiveState {
+	var state twitchLiveState
+	if cursor := feedCursorGet(feedTwitchLive, name); cursor != "" {
+		json.Unmarshal([]byte(cursor), &state)
+	}
+	return state
+}
+
+func setTwitchLiveState(name string, state twitchLiveState) {
+	if state.StreamID == "" {
+		feedCursorSet(feedTwitchLive, name, "")
+		return
+	}
+	if stateJson, err := json.Marshal(state); err == nil {
+		feedCursorSet(feedTwitchLive, name, string(stateJson))
+	}
+}
+
+func handleTwitchLive(channel configModuleTwitchChannel) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleTwitchLive(%s): ", channel.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.GreenString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Twitch Live \"%s\"", channel.Name))
+		l.ClearFlag()
+	}
+
+	login := parseTwitchLogin(channel.Login)
+	proxy := resolveProxy(channel.Proxy, twitchConfig.Proxy, twitchConfig.ProxyPool)
+	stream, err := getTwitchStream(login, proxy)
+	if err != nil {
+		return checkTwitchError(feedTwitchLive, channel.Name, fmt.Errorf("[%s] failed to fetch stream: %s", login, err))
+	}
+	state := getTwitchLiveState(channel.Name)
+
+	embedColor, err := hexdec(getTwitchColor(channel.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+	link := "https://www.twitch.tv/" + login
+
+	// Ended, or a new stream started between runs
+	if state.StreamID != "" && (stream == nil || stream.ID != state.StreamID) {
+		if channel.EditOnEnd == nil || *channel.EditOnEnd {
+			colorInt, _ := strconv.Atoi(embedColor)
+			endTwitchLivePosts(l, channel, state, link, colorInt)
+		}
+		state = twitchLiveState{}
+		setTwitchLiveState(channel.Name, state)
+	}
+	if stream == nil {
+		return nil
+	}
+
+	// Still live
+	if state.StreamID == stream.ID {
+		state.LastSeen = time.Now()
+		if stream.ViewerCount > state.PeakViewers {
+			state.PeakViewers = stream.ViewerCount
+		}
+		state.Title = stream.Title
+		state.Game = stream.GameName
+		missing := false
+		for _, destination := range channel.Destinations {
+			if _, posted := state.Messages[destination.Channel]; !posted &&
+				!refCheckSentToChannel(link+"#stream-"+stream.ID, destination.Channel) {
+				missing = true
+			}
+		}
+		if !missing {
+			setTwitchLiveState(channel.Name, state)
+			return nil
+		}
+		// Destinations that failed when it went live are retried on every poll while it's still live
+	}
+
+	// Went live
+	user, err := getTwitchUser(login, proxy)
+	if err != nil {
+		return checkTwitchError(feedTwitchLive, channel.Name, fmt.Errorf("[%s] failed to fetch channel: %s", login, err))
+	}
+	wentLive := state.StreamID != stream.ID
+	if wentLive {
+		thumbnail := strings.NewReplacer("{width}", "1280", "{height}", "720").Replace(stream.ThumbnailURL)
+		thumbnail += fmt.Sprintf("?t=%d", time.Now().Unix()) // Discord caches images by URL, the thumbnail changes throughout the stream
+		state = twitchLiveState{
+			StreamID:    stream.ID,
+			StartedAt:   stream.StartedAt,
+			LastSeen:    time.Now(),
+			PeakViewers: stream.ViewerCount,
+			Title:       stream.Title,
+			Game:        stream.GameName,
+			Thumbnail:   thumbnail,
+		}
+	}
+	if state.Messages == nil {
+		state.Messages = make(map[string]string)
+	}
+	thumbnail := state.Thumbnail
+
+	// Appearance Vars
+	username := user.DisplayName
+	if channel.Username != "" {
+		username = channel.Username
+	}
+	avatar := user.ProfileImageURL
+	if channel.Avatar != "" {
+		avatar = channel.Avatar
+	}
+	description := fmt.Sprintf("**%s** is live!", user.DisplayName)
+	gameField := "Game"
+	game := stream.GameName
+	if game == "" {
+		game = "—"
+	}
+	viewersField := "Viewers"
+	viewers := fmt.Sprint(stream.ViewerCount)
+	inline := true
+	footerText := "Twitch"
+	message := discordwebhook.Message{
+		Username:  &username,
+		AvatarUrl: &avatar,
+		Embeds: &[]discordwebhook.Embed{{
+			Title:       &stream.Title,
+			Url:         &link,
+			Description: &description,
+			Color:       &embedColor,
+			Author: &discordwebhook.Author{
+				Name:    &user.DisplayName,
+				Url:     &link,
+				IconUrl: &user.ProfileImageURL,
+			},
+			Fields: &[]discordwebhook.Field{
+				{Name: &gameField, Value: &game, Inline: &inline},
+				{Name: &viewersField, Value: &viewers, Inline: &inline},
+			},
+			Image: &discordwebhook.Image{Url: &thumbnail},
+			Footer: &discordwebhook.Footer{
+				Text:    &footerText,
+				IconUrl: &twitchLogo,
+			},
+		}},
+	}
+	item := refItem{
+		Ref:    link + "#stream-" + stream.ID,
+		URL:    link + "#stream-" + stream.ID, // a channel link alone would match every other stream as a duplicate
+		Title:  stream.Title,
+		Source: channel.Name,
+	}
+	for _, destination := range channel.Destinations {
+		if _, posted := state.Messages[destination.Channel]; posted {
+			continue
+		}
+		if refCheckSentToChannel(item.Ref, destination.Channel) {
+			continue
+		}
+		destMessage := message
+		if mentions := getDestinationMentions(destination); mentions != "" {
+			destMessage.Content = &mentions
+		}
+		// SEND
+		sendAttempts := 0
+	resend:
+		sendAttempts++
+		webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, link)
+		sent, err := sendWebhookItem(destination.Channel, item, destMessage, moduleNameTwitchLive)
+		if err != nil {
+			if strings.Contains(err.Error(), "resource is being rate limited") && sendAttempts < 5 {
+				log.Println(l.SetFlag(&lError).Log(
+					"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+				l.ClearFlag()
+				time.Sleep(3 * time.Second)
+				goto resend
+			}
+			log.Println(l.SetFlag(&lError).Log(
+				"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+			l.ClearFlag()
+			continue
+		}
+		state.Messages[destination.Channel] = sent.ID
+		markFeedNewItem(feedTwitchLive, channel.Name)
+		if generalConfig.Debug2 {
+			log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", link, destination.Channel))
+			l.ClearFlag()
+		}
+	}
+	setTwitchLiveState(channel.Name, state)
+	if !wentLive {
+		return nil
+	}
+
+	hints := getFeedHints(feedTwitchLive, channel.Name)
+	hints.ItemTimes = append(hints.ItemTimes, stream.StartedAt)
+	if len(hints.ItemTimes) > 20 {
+		hints.ItemTimes = hints.ItemTimes[len(hints.ItemTimes)-20:]
+	}
+	setFeedHints(feedTwitchLive, channel.Name, hints)
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Twitch Live %s went live", channel.Name))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+// Turns the live posts of a finished stream into a summary of it.
+func endTwitchLivePosts(l logInstructions, channel configModuleTwitchChannel, state twitchLiveState, link string, embedColor int) {
+	// Only known to the last poll, so the duration is as accurate as the feed's wait
+	duration := shortenTime(durafmt.ParseShort(state.LastSeen.Sub(state.StartedAt)).String())
+	game := state.Game
+	if game == "" {
+		game = "—"
+	}
+	embeds := []*discordgo.MessageEmbed{{
+		Title:       state.Title,
+		URL:         link,
+		Description: fmt.Sprintf("Stream ended, was live for **%s**", duration),
+		Color:       embedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Game", Value: game, Inline: true},
+			{Name: "Duration", Value: duration, Inline: true},
+			{Name: "Peak Viewers", Value: fmt.Sprint(state.PeakViewers), Inline: true},
+		},
+		Image: &discordgo.MessageEmbedImage{URL: state.Thumbnail},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text:    "Twitch",
+			IconURL: twitchLogo,
+		},
+	}}
+	for discordChannel, messageID := range state.Messages {
+		if err := editWebhookMessage(discordChannel, messageID, &discordgo.WebhookEdit{Embeds: &embeds}); err != nil {
+			log.Println(l.SetFlag(&lError).Log(
+				"Error editing ended stream post in %s: %s", discordChannel, err.Error()))
+			l.ClearFlag()
+		}
+	}
+}
+
+func handleTwitchLiveCmdOpts(config *configModuleTwitchChannel,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-channel"]; ok {
+		config.Login = parseTwitchLogin(opt.StringValue())
+	}
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			destClone := config.Destinations
+			for key, destination := range destClone {
+				if destination.Channel == i.ChannelID {
+					config.Destinations[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["role"]; ok {
+		role := opt.RoleValue(nil, "")
+		destClone := config.Destinations
+		for key, destination := range destClone {
+			if destination.Channel == i.ChannelID {
+				config.Destinations[key].Roles = []string{role.ID}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		config.WaitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		config.Username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		config.Avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		config.Color = opt.StringValue()
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["edit-on-end"]; ok {
+		val := opt.BoolValue()
+		config.EditOnEnd = &val
+	}
+	return nil
+}
+
+func newTwitchLiveFeedThread(channel configModuleTwitchChannel) feedThread {
+	thread := feedThread{
+		Group:       feedTwitchLive,
+		Name:        channel.Name,
+		Ref:         channel.Login,
+		Config:      channel,
+		WaitMins:    twitchConfig.WaitMins,
+		Adaptive:    twitchConfig.Adaptive,
+		MinWaitMins: twitchConfig.MinWaitMins,
+		MaxWaitMins: twitchConfig.MaxWaitMins,
+	}
+	if channel.WaitMins != nil {
+		thread.WaitMins = *channel.WaitMins
+	}
+	if channel.Adaptive != nil {
+		thread.Adaptive = *channel.Adaptive
+	}
+	if channel.MinWaitMins != nil {
+		thread.MinWaitMins = *channel.MinWaitMins
+	}
+	if channel.MaxWaitMins != nil {
+		thread.MaxWaitMins = *channel.MaxWaitMins
+	}
+	if channel.Enabled != nil {
+		thread.Paused = !*channel.Enabled
+	}
+	if channel.Schedule != "" {
+		setFeedSchedule(&thread, channel.Schedule)
+	} else {
+		setFeedSchedule(&thread, twitchConfig.Schedule)
+	}
+	return thread
+}
+
+func getTwitchLiveConfigIndex(name string) int {
+	for k, feed := range twitchConfig.Channels {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getTwitchLiveConfig(name string) *configModuleTwitchChannel {
+	i := getTwitchLiveConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &twitchConfig.Channels[i]
+	}
+}
+
+func existsTwitchLiveConfig(name string) bool {
+	return getTwitchLiveConfig(name) != nil
+}
+
+func updateTwitchLiveConfig(name string, config configModuleTwitchChannel) bool {
+	feedClone := twitchConfig.Channels
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			twitchConfig.Channels[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteTwitchLiveConfig(name string) error {
+	index := getTwitchLiveConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		twitchConfig.Channels = append(twitchConfig.Channels[:index], twitchConfig.Channels[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedTwitchLive) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("twitch channel config does not exist")
+}
+
+func setTwitchLiveConfigEnabled(name string, enabled bool) error {
+	config := getTwitchLiveConfig(name)
+	if config == nil {
+		return errors.New("twitch channel config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedTwitchLive, *config)
+	return nil
+}
+
+//#endregion
+
+//#region Chat
+
+const (
+	twitchChatDefaultAddress = "ircs://irc.chat.twitch.tv:6697"
+	twitchChatNick           = "justinfan31415" // anonymous, read only
+	twitchChatRateLimit      = 10
+	twitchChatDedupMins      = 5
+	twitchChatMaxBackoff     = 5 * time.Minute
+)
+
+// A parsed IRC line, with IRCv3 tags.
+type ircMessage struct {
+	Tags    map[string]string
+	Prefix  string // nick!user@host
+	Command string
+	Params  []string // the trailing parameter is last
+}
+
+var ircTagEscapes = strings.NewReplacer(`\:`, ";", `\s`, " ", `\\`, `\`, `\r`, "\r", `\n`, "\n")
+
+func parseIRCMessage(line string) ircMessage {
+	var message ircMessage
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "@") {
+		var tags string
+		tags, line, _ = strings.Cut(line[1:], " ")
+		message.Tags = make(map[string]string)
+		for _, tag := range strings.Split(tags, ";") {
+			key, value, _ := strings.Cut(tag, "=")
+			message.Tags[key] = ircTagEscapes.Replace(value)
+		}
+	}
+	if strings.HasPrefix(line, ":") {
+		message.Prefix, line, _ = strings.Cut(line[1:], " ")
+	}
+	var trailing string
+	hasTrailing := false
+	if index := strings.Index(line, " :"); index != -1 {
+		trailing = line[index+2:]
+		line = line[:index]
+		hasTrailing = true
+	}
+	fields := strings.Fields(line)
+	if len(fields) > 0 {
+		message.Command = strings.ToUpper(fields[0])
+		message.Params = fields[1:]
+	}
+	if hasTrailing {
+		message.Params = append(message.Params, trailing)
+	}
+	return message
+}
+
+func (m ircMessage) Nick() string {
+	nick, _, _ := strings.Cut(m.Prefix, "!")
+	return nick
+}
+
+func (m ircMessage) Trailing() string {
+	if len(m.Params) == 0 {
+		return ""
+	}
+	return m.Params[len(m.Params)-1]
+}
+
+// A chat message that matched a tracker, waiting to be sent.
+type twitchChatMatch struct {
+	Tracker configModuleTwitchChat
+	ID      string
+	Login   string
+	Display string
+	Text    string
+	Reason  string
+	Time    time.Time
+}
+
+var (
+	twitchChatQueue = make(chan twitchChatMatch, 100)
+	twitchChatSent  = make(map[string][]time.Time) // tracker to recent send times, for the rate limit
+	twitchChatSeen  = make(map[string]time.Time)   // tracker, user & text to when it was last forwarded
+	twitchChatMutex sync.Mutex
+)
+
+func getTwitchChatTrackers() []configModuleTwitchChat {
+	var trackers []configModuleTwitchChat
+	for _, tracker := range twitchConfig.Chat {
+		if tracker.Enabled == nil || *tracker.Enabled {
+			trackers = append(trackers, tracker)
+		}
+	}
+	return trackers
+}
+
+// Keeps a chat connection open while there are trackers, reconnecting with backoff when it drops.
+// Trackers are only read from twitch.json, so without any there's nothing to do until a restart.
+func runTwitchChat() {
+	if len(getTwitchChatTrackers()) == 0 {
+		return
+	}
+	l := logInstructions{
+		Location: "runTwitchChat(): ",
+		Task:     "",
+		Inline:   false,
+		Color:    color.MagentaString,
+	}
+	go runTwitchChatSender()
+
+	address := twitchConfig.ChatAddress
+	if address == "" {
+		address = twitchChatDefaultAddress
+	}
+	backoff := 5 * time.Second
+	for ctxRoot.Err() == nil {
+		start := time.Now()
+		err := connectTwitchChat(address, getTwitchChatTrackers())
+		if ctxRoot.Err() != nil {
+			return
+		}
+		if time.Since(start) > time.Minute { // was up for a while, not a connection loop
+			backoff = 5 * time.Second
+		}
+		log.Println(l.SetFlag(&lError).Log("Twitch chat disconnected (%v), reconnecting in %s...", err, backoff))
+		l.ClearFlag()
+		select {
+		case <-ctxRoot.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > twitchChatMaxBackoff {
+			backoff = twitchChatMaxBackoff
+		}
+	}
+}
+
+func dialTwitchChat(address string) (net.Conn, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	switch u.Scheme {
+	case "ircs":
+		return tls.DialWithDialer(dialer, "tcp", u.Host, &tls.Config{ServerName: u.Hostname()})
+	case "irc":
+		return dialer.DialContext(ctxRoot, "tcp", u.Host)
+	}
+	return nil, fmt.Errorf("unsupported chat address scheme \"%s\", use irc or ircs", u.Scheme)
+}
+
+// Joins the trackers' channels and handles chat until the connection drops or the bot shuts down.
+func connectTwitchChat(address string, trackers []configModuleTwitchChat) error {
+	conn, err := dialTwitchChat(address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	send := func(line string) error {
+		conn.SetWriteDeadline(time.Now().Add(30 * time.Second))
+		_, err := conn.Write([]byte(line + "\r\n"))
+		return err
+	}
+	if err := send("CAP REQ :twitch.tv/tags twitch.tv/commands"); err != nil {
+		return err
+	}
+	if err := send("NICK " + twitchChatNick); err != nil {
+		return err
+	}
+	byChannel := make(map[string][]configModuleTwitchChat)
+	for _, tracker := range trackers {
+		channel := "#" + parseTwitchLogin(tracker.Channel)
+		if _, joined := byChannel[channel]; !joined {
+			if err := send("JOIN " + channel); err != nil {
+				return err
+			}
+		}
+		byChannel[channel] = append(byChannel[channel], tracker)
+	}
+
+	// Read separately so shutdown isn't held up waiting on chat
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		reader := bufio.NewReader(conn)
+		for {
+			conn.SetReadDeadline(time.Now().Add(10 * time.Minute)) // Twitch pings every ~5 minutes
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case lines <- line:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		var line string
+		select {
+		case <-ctxRoot.Done():
+			return ctxRoot.Err()
+		case err := <-readErr:
+			return err
+		case line = <-lines:
+		}
+		message := parseIRCMessage(line)
+		switch message.Command {
+		case "PING":
+			if err := send("PONG :" + message.Trailing()); err != nil {
+				return err
+			}
+		case "RECONNECT": // server going down for maintenance
+			return errors.New("server asked to reconnect")
+		case "PRIVMSG", "USERNOTICE":
+			if len(message.Params) == 0 {
+				continue
+			}
+			for _, tracker := range byChannel[strings.ToLower(message.Params[0])] {
+				if match, ok := matchTwitchChat(tracker, message); ok {
+					select {
+					case twitchChatQueue <- match:
+					default: // sender is backed up, dropping is better than stalling the connection
+					}
+				}
+			}
+		}
+	}
+}
+
+// Checks a chat message against a tracker's rules, returning why it matched.
+func matchTwitchChat(tracker configModuleTwitchChat, message ircMessage) (twitchChatMatch, bool) {
+	login := message.Tags["login"] // USERNOTICE
+	if login == "" {
+		login = message.Nick()
+	}
+	display := message.Tags["display-name"]
+	if display == "" {
+		display = login
+	}
+	text := ""
+	if message.Command == "PRIVMSG" || len(message.Params) > 1 {
+		text = message.Trailing()
+	}
+	match := twitchChatMatch{
+		Tracker: tracker,
+		ID:      message.Tags["id"],
+		Login:   login,
+		Display: display,
+		Text:    text,
+		Time:    time.Now(),
+	}
+
+	if message.Command == "USERNOTICE" {
+		switch message.Tags["msg-id"] {
+		case "sub", "resub", "subgift", "submysterygift", "giftpaidupgrade", "primepaidupgrade":
+			if tracker.IncludeSubs != nil && *tracker.IncludeSubs {
+				match.Reason = "Subscription"
+				if system := message.Tags["system-msg"]; system != "" {
+					match.Text = strings.TrimSpace(system + "\n" + text)
+				}
+				return match, true
+			}
+		}
+		return match, false
+	}
+
+	for _, user := range tracker.Users {
+		if strings.EqualFold(strings.TrimPrefix(user, "@"), login) {
+			match.Reason = "Tracked User"
+			return match, true
+		}
+	}
+	if tracker.IncludeModerators != nil && *tracker.IncludeModerators &&
+		(message.Tags["mod"] == "1" || strings.Contains(message.Tags["badges"], "broadcaster/")) {
+		match.Reason = "Moderator"
+		return match, true
+	}
+	if bits, _ := strconv.Atoi(message.Tags["bits"]); bits > 0 && tracker.IncludeBits != nil && *tracker.IncludeBits {
+		match.Reason = fmt.Sprintf("Cheered %d Bit%s", bits, ssuff(bits))
+		return match, true
+	}
+	lowerText := strings.ToLower(text)
+	for _, keyword := range tracker.Keywords {
+		if keyword != "" && strings.Contains(lowerText, strings.ToLower(keyword)) {
+			match.Reason = "Keyword: " + keyword
+			return match, true
+		}
+	}
+	return match, false
+}
+
+// Rate limit & dedup for a tracker, reserving a send if allowed.
+func allowTwitchChatMatch(match twitchChatMatch) bool {
+	rateLimit := twitchChatRateLimit
+	if match.Tracker.RateLimit != nil {
+		rateLimit = *match.Tracker.RateLimit
+	}
+	dedup := time.Duration(twitchChatDedupMins) * time.Minute
+	if match.Tracker.DedupMins != nil {
+		dedup = time.Duration(*match.Tracker.DedupMins) * time.Minute
+	}
+
+	twitchChatMutex.Lock()
+	defer twitchChatMutex.Unlock()
+	seenKey := strings.ToLower(match.Tracker.Name + "\x00" + match.Login + "\x00" + strings.TrimSpace(match.Text))
+	if seen, ok := twitchChatSeen[seenKey]; ok && match.Time.Sub(seen) < dedup {
+		return false
+	}
+	var recent []time.Time
+	for _, sent := range twitchChatSent[match.Tracker.Name] {
+		if match.Time.Sub(sent) < time.Minute {
+			recent = append(recent, sent)
+		}
+	}
+	if rateLimit > 0 && len(recent) >= rateLimit {
+		twitchChatSent[match.Tracker.Name] = recent
+		return false
+	}
+	twitchChatSent[match.Tracker.Name] = append(recent, match.Time)
+	twitchChatSeen[seenKey] = match.Time
+	// Forget old messages so the map doesn't grow forever
+	for key, seen := range twitchChatSeen {
+		if match.Time.Sub(seen) > time.Hour && match.Time.Sub(seen) > dedup {
+			delete(twitchChatSeen, key)
+		}
+	}
+	return true
+}
+
+// Sends matched chat messages to Discord, separate from the connection so slow webhooks don't hold up chat.
+func runTwitchChatSender() {
+	l := logInstructions{
+		Location: "runTwitchChatSender(): ",
+		Task:     "",
+		Inline:   false,
+		Color:    color.MagentaString,
+	}
+	for {
+		select {
+		case <-ctxRoot.Done():
+			return
+		case match := <-twitchChatQueue:
+			if !allowTwitchChatMatch(match) {
+				if generalConfig.Debug2 {
+					log.Println(l.SetFlag(&lDebug2).LogI(true, "DROPPED chat from %s in %s (rate limit or repeat)",
+						match.Login, match.Tracker.Name))
+					l.ClearFlag()
+				}
+				continue
+			}
+			sendTwitchChatMatch(l, match)
+		}
+	}
+}
+
+func sendTwitchChatMatch(l logInstructions, match twitchChatMatch) {
+	tracker := match.Tracker
+	channel := parseTwitchLogin(tracker.Channel)
+	link := "https://www.twitch.tv/" + channel
+	embedColor, err := hexdec(getTwitchColor(tracker.Color))
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+
+	// Appearance Vars
+	username := channel + " chat"
+	if tracker.Username != "" {
+		username = tracker.Username
+	}
+	avatar := twitchLogo
+	if tracker.Avatar != "" {
+		avatar = tracker.Avatar
+	}
+	userLink := "https://www.twitch.tv/" + match.Login
+	description := markdownEscaper.Replace(match.Text)
+	footerText := "Twitch Chat · #" + channel
+	message := discordwebhook.Message{
+		Username:  &username,
+		AvatarUrl: &avatar,
+		Embeds: &[]discordwebhook.Embed{{
+			Title:       &match.Reason,
+			Url:         &link,
+			Description: &description,
+			Color:       &embedColor,
+			Author: &discordwebhook.Author{
+				Name: &match.Display,
+				Url:  &userLink,
+			},
+			Footer: &discordwebhook.Footer{
+				Text:    &footerText,
+				IconUrl: &twitchLogo,
+			},
+		}},
+	}
+	// Chat lines aren't logged as refs, chat is never read twice and busy channels would flood the database.
+	// Repeats are dropped in memory by allowTwitchChatMatch() instead.
+	for _, destination := range tracker.Destinations {
+		destMessage := message
+		if mentions := getDestinationMentions(destination); mentions != "" {
+			destMessage.Content = &mentions
+		}
+		webhook, err := getWebhookForChannel(destination.Channel)
+		if err == nil {
+			_, err = executeWebhook(getWebhookURL(webhook), destMessage)
+		}
+		if err != nil {
+			log.Println(l.SetFlag(&lError).Log(
+				"WEBHOOK to %s (\"%s\") encountered an error while sending: %s", destination.Channel, tracker.Name, err.Error()))
+			l.ClearFlag()
+		}
+	}
+}
+
+//#endregion
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIRCMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want ircMessage
+	}{
+		{
+			name: "ping",
+			line: "PING :tmi.twitch.tv\r\n",
+			want: ircMessage{Command: "PING", Params: []string{"tmi.twitch.tv"}},
+		},
+		{
+			name: "privmsg with tags",
+			line: "@badges=moderator/1;display-name=Nasa;mod=1 :nasa!nasa@nasa.tmi.twitch.tv PRIVMSG #space :liftoff in 10\r\n",
+			want: ircMessage{
+				Tags:    map[string]string{"badges": "moderator/1", "display-name": "Nasa", "mod": "1"},
+				Prefix:  "nasa!nasa@nasa.tmi.twitch.tv",
+				Command: "PRIVMSG",
+				Params:  []string{"#space", "liftoff in 10"},
+			},
+		},
+		{
+			name: "escaped tag values",
+			line: `@system-msg=Nasa\ssubscribed\:\sthanks\\;flag= :tmi.twitch.tv USERNOTICE #space`,
+			want: ircMessage{
+				Tags:    map[string]string{"system-msg": `Nasa subscribed; thanks\`, "flag": ""},
+				Prefix:  "tmi.twitch.tv",
+				Command: "USERNOTICE",
+				Params:  []string{"#space"},
+			},
+		},
+		{
+			name: "trailing keeps colons and spaces",
+			line: ":nasa!nasa@host PRIVMSG #space :T-minus: 10  seconds",
+			want: ircMessage{Prefix: "nasa!nasa@host", Command: "PRIVMSG", Params: []string{"#space", "T-minus: 10  seconds"}},
+		},
+		{
+			name: "empty trailing",
+			line: ":nasa!nasa@host PRIVMSG #space :",
+			want: ircMessage{Prefix: "nasa!nasa@host", Command: "PRIVMSG", Params: []string{"#space", ""}},
+		},
+		{
+			name: "lowercase command",
+			line: "reconnect",
+			want: ircMessage{Command: "RECONNECT", Params: []string{}},
+		},
+		{
+			name: "empty line",
+			line: "\r\n",
+			want: ircMessage{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseIRCMessage(test.line)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseIRCMessage(%q) = %#v, want %#v", test.line, got, test.want)
+			}
+		})
+	}
+
+	message := parseIRCMessage(":nasa!nasa@host PRIVMSG #space :hello")
+	if message.Nick() != "nasa" || message.Trailing() != "hello" {
+		t.Errorf("Nick() = %q, Trailing() = %q", message.Nick(), message.Trailing())
+	}
+}
+
+func TestMatchTwitchChat(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name       string
+		tracker    configModuleTwitchChat
+		line       string
+		wantMatch  bool
+		wantReason string
+		wantLogin  string
+		wantText   string
+	}{
+		{
+			name:       "keyword is case insensitive",
+			tracker:    configModuleTwitchChat{Keywords: []string{"Liftoff"}},
+			line:       "@display-name=Fan :fan!fan@host PRIVMSG #space :LIFTOFF soon?",
+			wantMatch:  true,
+			wantReason: "Keyword: Liftoff",
+			wantLogin:  "fan",
+			wantText:   "LIFTOFF soon?",
+		},
+		{
+			name:      "no keyword",
+			tracker:   configModuleTwitchChat{Keywords: []string{"liftoff", ""}},
+			line:      ":fan!fan@host PRIVMSG #space :hello",
+			wantLogin: "fan",
+			wantText:  "hello",
+		},
+		{
+			name:       "tracked user with an @",
+			tracker:    configModuleTwitchChat{Users: []string{"@NASA"}},
+			line:       ":nasa!nasa@host PRIVMSG #space :hello",
+			wantMatch:  true,
+			wantReason: "Tracked User",
+			wantLogin:  "nasa",
+			wantText:   "hello",
+		},
+		{
+			name:      "moderators are off by default",
+			tracker:   configModuleTwitchChat{},
+			line:      "@mod=1 :mod!mod@host PRIVMSG #space :hello",
+			wantLogin: "mod",
+			wantText:  "hello",
+		},
+		{
+			name:       "moderator",
+			tracker:    configModuleTwitchChat{IncludeModerators: &yes},
+			line:       "@mod=1 :mod!mod@host PRIVMSG #space :hello",
+			wantMatch:  true,
+			wantReason: "Moderator",
+			wantLogin:  "mod",
+			wantText:   "hello",
+		},
+		{
+			name:       "broadcaster counts as a moderator",
+			tracker:    configModuleTwitchChat{IncludeModerators: &yes},
+			line:       "@badges=broadcaster/1,subscriber/0;mod=0 :space!space@host PRIVMSG #space :hello",
+			wantMatch:  true,
+			wantReason: "Moderator",
+			wantLogin:  "space",
+			wantText:   "hello",
+		},
+		{
+			name:       "bits",
+			tracker:    configModuleTwitchChat{IncludeBits: &yes},
+			line:       "@bits=100 :fan!fan@host PRIVMSG #space :cheer100 go",
+			wantMatch:  true,
+			wantReason: "Cheered 100 Bits",
+			wantLogin:  "fan",
+			wantText:   "cheer100 go",
+		},
+		{
+			name:       "subscription uses the system message",
+			tracker:    configModuleTwitchChat{IncludeSubs: &yes},
+			line:       `@msg-id=resub;login=fan;display-name=Fan;system-msg=Fan\ssubscribed :tmi.twitch.tv USERNOTICE #space :still here`,
+			wantMatch:  true,
+			wantReason: "Subscription",
+			wantLogin:  "fan",
+			wantText:   "Fan subscribed\nstill here",
+		},
+		{
+			name:      "subscriptions are off by default",
+			tracker:   configModuleTwitchChat{Keywords: []string{"subscribed"}},
+			line:      `@msg-id=sub;login=fan;system-msg=Fan\ssubscribed :tmi.twitch.tv USERNOTICE #space`,
+			wantLogin: "fan",
+		},
+		{
+			name:      "other notices never match",
+			tracker:   configModuleTwitchChat{IncludeSubs: &yes, Keywords: []string{"raid"}},
+			line:      `@msg-id=raid;login=fan :tmi.twitch.tv USERNOTICE #space :raid`,
+			wantLogin: "fan",
+			wantText:  "raid",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			match, ok := matchTwitchChat(test.tracker, parseIRCMessage(test.line))
+			if ok != test.wantMatch {
+				t.Fatalf("matched = %v, want %v", ok, test.wantMatch)
+			}
+			if match.Reason != test.wantReason {
+				t.Errorf("reason = %q, want %q", match.Reason, test.wantReason)
+			}
+			if match.Login != test.wantLogin {
+				t.Errorf("login = %q, want %q", match.Login, test.wantLogin)
+			}
+			if match.Text != test.wantText {
+				t.Errorf("text = %q, want %q", match.Text, test.wantText)
+			}
+		})
+	}
+}
+
+func TestAllowTwitchChatMatch(t *testing.T) {
+	two, zero, oneMin := 2, 0, 1
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	type send struct {
+		login  string
+		text   string
+		after  time.Duration // since start
+		wanted bool
+	}
+	tests := []struct {
+		name    string
+		tracker configModuleTwitchChat
+		sends   []send
+	}{
+		{
+			name:    "rate limit per minute",
+			tracker: configModuleTwitchChat{Name: "a", RateLimit: &two},
+			sends: []send{
+				{"fan1", "one", 0, true},
+				{"fan2", "two", time.Second, true},
+				{"fan3", "three", 2 * time.Second, false},
+				{"fan4", "four", time.Minute + time.Second, true},
+			},
+		},
+		{
+			name:    "zero is unlimited",
+			tracker: configModuleTwitchChat{Name: "b", RateLimit: &zero},
+			sends: []send{
+				{"fan1", "one", 0, true},
+				{"fan2", "two", 0, true},
+				{"fan3", "three", 0, true},
+			},
+		},
+		{
+			name:    "same text from the same user is dropped",
+			tracker: configModuleTwitchChat{Name: "c"},
+			sends: []send{
+				{"fan", "Liftoff", 0, true},
+				{"FAN", " liftoff ", time.Minute, false},
+				{"other", "liftoff", time.Minute, true},
+				{"fan", "liftoff", 6 * time.Minute, true},
+			},
+		},
+		{
+			name:    "custom dedup window",
+			tracker: configModuleTwitchChat{Name: "d", DedupMins: &oneMin},
+			sends: []send{
+				{"fan", "liftoff", 0, true},
+				{"fan", "liftoff", 30 * time.Second, false},
+				{"fan", "liftoff", 2 * time.Minute, true},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			twitchChatMutex.Lock()
+			twitchChatSent = make(map[string][]time.Time)
+			twitchChatSeen = make(map[string]time.Time)
+			twitchChatMutex.Unlock()
+			for k, send := range test.sends {
+				match := twitchChatMatch{Tracker: test.tracker, Login: send.login, Text: send.text, Time: start.Add(send.after)}
+				if got := allowTwitchChatMatch(match); got != send.wanted {
+					t.Errorf("send %d (%s: %q) allowed = %v, want %v", k, send.login, send.text, got, send.wanted)
+				}
+			}
+		})
+	}
+}
+
+// Stands in for Twitch's chat server, one client at a time.
+type testIRCServer struct {
+	t        *testing.T
+	listener net.Listener
+	conn     net.Conn
+	reader   *bufio.Reader
+}
+
+func newTestIRCServer(t *testing.T) *testIRCServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	return &testIRCServer{t: t, listener: listener}
+}
+
+func (s *testIRCServer) Address() string {
+	return "irc://" + s.listener.Addr().String()
+}
+
+func (s *testIRCServer) Accept() {
+	s.t.Helper()
+	s.listener.(*net.TCPListener).SetDeadline(time.Now().Add(5 * time.Second))
+	conn, err := s.listener.Accept()
+	if err != nil {
+		s.t.Fatalf("client never connected: %s", err)
+	}
+	s.t.Cleanup(func() { conn.Close() })
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+}
+
+// Reads the next n lines from the client, sorted since joins and parts aren't in any order.
+func (s *testIRCServer) Expect(want ...string) {
+	s.t.Helper()
+	var got []string
+	for range want {
+		s.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			s.t.Fatalf("got %q, then failed to read: %s", got, err)
+		}
+		got = append(got, strings.TrimRight(line, "\r\n"))
+	}
+	sort.Strings(got)
+	want = append([]string(nil), want...)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		s.t.Fatalf("client sent %q, want %q", got, want)
+	}
+}
+
+func (s *testIRCServer) Send(line string) {
+	s.t.Helper()
+	s.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	if _, err := s.conn.Write([]byte(line + "\r\n")); err != nil {
+		s.t.Fatalf("failed to send: %s", err)
+	}
+}
+
+// Replaces the chat trackers for the length of the test.
+func setupTestTwitchChat(t *testing.T, trackers []configModuleTwitchChat) {
+	t.Helper()
+	previous := twitchConfig.Chat
+	twitchConfig.Chat = trackers
+	twitchChatMutex.Lock()
+	twitchChatSent = make(map[string][]time.Time)
+	twitchChatSeen = make(map[string]time.Time)
+	twitchChatMutex.Unlock()
+	t.Cleanup(func() { twitchConfig.Chat = previous })
+}
+
+func expectTwitchChatMatch(t *testing.T, wantReason string, wantText string) {
+	t.Helper()
+	select {
+	case match := <-twitchChatQueue:
+		if match.Reason != wantReason || match.Text != wantText {
+			t.Fatalf("queued %q: %q, want %q: %q", match.Reason, match.Text, wantReason, wantText)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("nothing queued, want %q: %q", wantReason, wantText)
+	}
+}
+
+func expectNoTwitchChatMatch(t *testing.T) {
+	t.Helper()
+	select {
+	case match := <-twitchChatQueue:
+		t.Fatalf("queued %q: %q, want nothing", match.Reason, match.Text)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestConnectTwitchChat(t *testing.T) {
+	no := false
+	setupTestTwitchChat(t, []configModuleTwitchChat{
+		{Name: "Space", Channel: "https://www.twitch.tv/Space", Keywords: []string{"liftoff"}},
+		{Name: "Space Crew", Channel: "space", Users: []string{"nasa"}},
+		{Name: "Off", Channel: "off", Enabled: &no, Keywords: []string{"liftoff"}},
+	})
+	server := newTestIRCServer(t)
+	result := make(chan error, 1)
+	trackers := getTwitchChatTrackers()
+	go func() { result <- connectTwitchChat(server.Address(), trackers) }()
+	server.Accept()
+
+	server.Expect("CAP REQ :twitch.tv/tags twitch.tv/commands", "NICK "+twitchChatNick, "JOIN #space")
+	server.Send("PING :tmi.twitch.tv")
+	server.Expect("PONG :tmi.twitch.tv")
+
+	server.Send(":fan!fan@host PRIVMSG #space :liftoff!")
+	expectTwitchChatMatch(t, "Keyword: liftoff", "liftoff!")
+	server.Send(":nasa!nasa@host PRIVMSG #space :hello")
+	expectTwitchChatMatch(t, "Tracked User", "hello")
+	server.Send(":fan!fan@host PRIVMSG #space :hello")
+	server.Send(":fan!fan@host PRIVMSG #off :liftoff")
+	expectNoTwitchChatMatch(t)
+
+	server.Send(":tmi.twitch.tv RECONNECT")
+	select {
+	case err := <-result:
+		if err == nil || !strings.Contains(err.Error(), "reconnect") {
+			t.Errorf("error = %v, want a reconnect", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("still connected after RECONNECT")
+	}
+}
+
+func TestConnectTwitchChatDropped(t *testing.T) {
+	setupTestTwitchChat(t, nil)
+	server := newTestIRCServer(t)
+	result := make(chan error, 1)
+	go func() {
+		result <- connectTwitchChat(server.Address(), []configModuleTwitchChat{{Name: "Space", Channel: "space"}})
+	}()
+	server.Accept()
+	server.Expect("CAP REQ :twitch.tv/tags twitch.tv/commands", "NICK "+twitchChatNick, "JOIN #space")
+
+	server.conn.Close()
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Error("no error after the server hung up")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("still connected after the server hung up")
+	}
+}
+
+func TestSendTwitchChatMatch(t *testing.T) {
+	setupTestDatabase(t)
+	fake := setupTestDiscord(t)
+	tracker := configModuleTwitchChat{
+		Name:         "Space",
+		Channel:      "space",
+		Destinations: []feedDestination{{Channel: "100"}, {Channel: "200", Tags: []string{"300"}}},
+	}
+	match := twitchChatMatch{Tracker: tracker, ID: "1", Login: "fan", Display: "Fan", Text: "*liftoff*", Reason: "Keyword: liftoff", Time: time.Now()}
+	sendTwitchChatMatch(logInstructions{}, match)
+
+	messages := fake.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(messages))
+	}
+	embed := (*messages[0].Message.Embeds)[0]
+	if *embed.Description != `\*liftoff\*` || *embed.Author.Name != "Fan" || *embed.Title != "Keyword: liftoff" {
+		t.Errorf("embed = %q by %q (%q)", *embed.Description, *embed.Author.Name, *embed.Title)
+	}
+	if messages[1].Message.Content == nil || *messages[1].Message.Content != "<@300>" {
+		t.Errorf("second destination wasn't tagged")
+	}
+
+	var refs int64
+	dbRefs.Model(&dbRef{}).Count(&refs)
+	if refs != 0 {
+		t.Errorf("%d chat lines logged as refs, want none", refs)
+	}
+}
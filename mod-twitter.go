@@ -5,11 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dustin/go-humanize"
@@ -23,6 +29,7 @@ var (
 	twitterConfig           configModuleTwitter
 
 	moduleNameTwitterAccounts = "twitter-accounts"
+	moduleNameTwitterTrends   = "twitter-trends"
 
 	twitterLogo = "https://i.imgur.com/BEZiTLN.png"
 
@@ -35,9 +42,20 @@ type configModuleTwitter struct {
 	WaitMins int `json:"waitMins,omitempty"`
 	//DayLimit int `json:"dayLimit,omitempty"` // X days = too old, ignored
 
+	Adaptive    bool   `json:"adaptive,omitempty"`    // poll based on account activity instead of waitMins
+	MinWaitMins int    `json:"minWaitMins,omitempty"` // adaptive lower bound, default 5
+	MaxWaitMins int    `json:"maxWaitMins,omitempty"` // adaptive upper bound, default 1440
+	Schedule    string `json:"schedule,omitempty"`    // schedule expression, see schedule.go
+
+	SessionCooldownMins int `json:"sessionCooldownMins,omitempty"` // X minutes a rate limited session is rested, default 15
+
+	Proxy     string   `json:"proxy,omitempty"`     // overrides the general proxy, see proxy.go
+	ProxyPool []string `json:"proxyPool,omitempty"` // rotated through on each fetch instead of proxy
+
 	DefaultColor string `json:"defaultColor,omitempty"`
 
-	Accounts []configModuleTwitterAcc `json:"accounts"`
+	Accounts []configModuleTwitterAcc    `json:"accounts"`
+	Trends   []configModuleTwitterTrends `json:"trends,omitempty"`
 }
 
 type configModuleTwitterAcc struct {
@@ -45,8 +63,14 @@ type configModuleTwitterAcc struct {
 	Name         string            `json:"name"`
 	Handle       string            `json:"handle"`
 	Destinations []feedDestination `json:"destinations"`
-
-	WaitMins *int `json:"waitMins,omitempty"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins    *int   `json:"waitMins,omitempty"`
+	Adaptive    *bool  `json:"adaptive,omitempty"`
+	MinWaitMins *int   `json:"minWaitMins,omitempty"`
+	MaxWaitMins *int   `json:"maxWaitMins,omitempty"`
+	Schedule    string `json:"schedule,omitempty"`
+	Proxy       string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
 	//DayLimit *int `json:"dayLimit,omitempty"` // X days = too old, ignored
 
 	// APPEARANCE
@@ -66,6 +90,27 @@ type configModuleTwitterAcc struct {
 	FilterType      string `json:"filterType,omitempty"`
 }
 
+type configModuleTwitterTrends struct {
+	// MAIN
+	Name         string            `json:"name"`
+	Location     string            `json:"location"` // WOEID or place name, worldwide if empty
+	Destinations []feedDestination `json:"destinations"`
+	Enabled      *bool             `json:"enabled,omitempty"` // paused if false
+
+	WaitMins *int   `json:"waitMins,omitempty"`
+	Schedule string `json:"schedule,omitempty"`
+	Proxy    string `json:"proxy,omitempty"` // "direct" to skip the module/general proxy
+
+	// APPEARANCE
+	Username string `json:"username,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Color    string `json:"color,omitempty"`
+
+	// RULES
+	Top      *int     `json:"top,omitempty"`      // size of the ranked list, default 10, max 50
+	Keywords []string `json:"keywords,omitempty"` // watched terms, destinations are pinged when one starts trending
+}
+
 func loadConfig_Module_Twitter() error {
 	prefixHere := "loadConfig_Module_Twitter(): "
 	// TODO: Creation prompts if missing
@@ -88,6 +133,9 @@ func loadConfig_Module_Twitter() error {
 			if err = json.Unmarshal([]byte(configStr), &twitterConfig); err != nil {
 				return fmt.Errorf("failed to parse twitter config file: %s", err)
 			}
+			if err = checkProxySettings(twitterConfig.Proxy, twitterConfig.ProxyPool); err != nil {
+				return fmt.Errorf("invalid twitter proxy settings: %s", err)
+			}
 			// Output?
 			if generalConfig.OutputSettings {
 				s, err := json.MarshalIndent(twitterConfig, "", "\t")
@@ -109,13 +157,51 @@ func loadConfig_Module_Twitter() error {
 }
 
 var (
-	twitterUsername  string
+	twitterUsername  string // first account, from the top of credentials.ini
 	twitterPassword  string
-	twitterConnected bool = false
+	twitterLogins    []twitterLogin         // every account, see loadConfig_Modules_Credentials
+	twitterConnected bool           = false // any session is logged in
 
-	twitterScraper *twitterscraper.Scraper
+	twitterSessions     []*twitterSession
+	twitterSessionMutex sync.Mutex
+	twitterSessionNext  int
 )
 
+type twitterLogin struct {
+	Username string
+	Password string
+}
+
+// One scraper logged in to one account, requests are spread across all of them.
+type twitterSession struct {
+	Username   string // empty for the guest session used when no credentials are set
+	Password   string
+	CookiePath string
+
+	Scraper       *twitterscraper.Scraper
+	Proxy         string // proxy the scraper is currently set to
+	Connected     bool
+	LoggingIn     bool
+	LastLogin     time.Time // last login attempt
+	CooldownUntil time.Time // rate limited, skipped until then
+	LastUsed      time.Time
+	Alerted       bool // admins were told the login failed
+
+	inUse sync.Mutex
+}
+
+const (
+	twitterLoginAttempts   = 3
+	twitterReloginInterval = 30 * time.Minute // wait between automatic re-logins of a failed session
+)
+
+func getTwitterSessionCooldown() time.Duration {
+	if twitterConfig.SessionCooldownMins > 0 {
+		return time.Duration(twitterConfig.SessionCooldownMins) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
 func openTwitter() error {
 	l := logInstructions{
 		Location: "openTwitter",
@@ -124,84 +210,398 @@ func openTwitter() error {
 		Color:    color.MagentaString,
 	}
 
-	twitterImport := func() error {
-		f, err := os.Open(pathDataCookiesTwitter)
-		if err != nil {
-			return err
+	twitterSessionMutex.Lock()
+	twitterSessions = nil
+	for k, login := range twitterLogins {
+		cookiePath := pathDataCookiesTwitter // first account keeps the original cookie file
+		if k > 0 {
+			cookiePath = pathDataCookies + string(os.PathSeparator) + "twitter-" + sanitizeFileName(login.Username) + ".json"
 		}
-		var cookies []*http.Cookie
-		err = json.NewDecoder(f).Decode(&cookies)
-		if err != nil {
-			return err
+		twitterSessions = append(twitterSessions, &twitterSession{
+			Username:   login.Username,
+			Password:   login.Password,
+			CookiePath: cookiePath,
+		})
+	}
+	twitterSessionMutex.Unlock()
+
+	if len(twitterSessions) == 0 {
+		log.Println(l.LogI(true, "Twitter (X) credentials missing, the bot will not fetch this media..."))
+		session := &twitterSession{Scraper: twitterscraper.New()}
+		if err := session.setProxy(resolveProxy("", twitterConfig.Proxy, twitterConfig.ProxyPool)); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Proxy Error: %s", err.Error()))
+			l.ClearFlag()
 		}
-		twitterScraper.SetCookies(cookies)
-		twitterScraper.IsLoggedIn()
-		_, err = twitterScraper.GetProfile("x")
-		if err != nil {
-			return err
+		twitterSessions = append(twitterSessions, session)
+		return nil
+	}
+
+	log.Println(l.LogI(true, "Connecting to Twitter (X) with %d account%s...", len(twitterSessions), ssuff(len(twitterSessions))))
+	for _, session := range twitterSessions {
+		loginTwitterSession(session)
+	}
+	updateTwitterConnected()
+
+	return nil
+}
+
+// Logs a session in from its cookie cache, or with its credentials if that fails, trying a few times.
+func loginTwitterSession(session *twitterSession) error {
+	l := logInstructions{
+		Location: "loginTwitterSession",
+		Task:     "@" + session.Username,
+		Inline:   false,
+		Color:    color.MagentaString,
+	}
+
+	twitterSessionMutex.Lock()
+	session.LastLogin = time.Now()
+	session.Connected = false
+	twitterSessionMutex.Unlock()
+	session.Scraper = twitterscraper.New()
+	session.Proxy = ""
+	proxy := resolveProxy("", twitterConfig.Proxy, twitterConfig.ProxyPool)
+	if err := session.setProxy(proxy); err != nil {
+		log.Println(l.SetFlag(&lError).Log("Proxy Error: %s", err.Error()))
+		l.ClearFlag()
+	}
+
+	if err := session.importCookies(); err == nil {
+		log.Println(l.LogCI(color.HiMagentaString, true, "Connected to @%s via cache", session.Username))
+		session.setConnected(true)
+		return nil
+	}
+
+	var err error
+	for attempt := 1; attempt <= twitterLoginAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(3 * time.Second)
+		}
+		session.Scraper.ClearCookies()
+		if err = session.Scraper.Login(session.Username, session.Password); err != nil {
+			if isProxyError(err) { // not for bad credentials or challenges, the proxy isn't at fault
+				markProxyFailed(proxy)
+			}
+			log.Println(l.SetFlag(&lError).Log("Login Error: %s", err.Error()))
+			l.ClearFlag()
+			continue
+		}
+		// Connected!
+		session.setConnected(true)
+		if session.Scraper.IsLoggedIn() {
+			log.Println(l.LogCI(color.HiMagentaString, true, "Connected to @%s via new login", session.Username))
+		} else {
+			log.Println(l.SetFlag(&lWarning).Log(
+				"Scraper login seemed successful but bot is not logged in, Twitter (X) parsing may not work..."))
+			l.ClearFlag()
+		}
+		if err := session.exportCookies(); err != nil {
+			log.Println(l.SetFlag(&lError).Log("Failed to save cookies: %s", err.Error()))
+			l.ClearFlag()
 		}
 		return nil
 	}
 
-	twitterExport := func() error {
-		cookies := twitterScraper.GetCookies()
-		js, err := json.Marshal(cookies)
+	log.Println(l.SetFlag(&lError).Log("Failed to login to Twitter (X) as @%s, this account won't be used...", session.Username))
+	l.ClearFlag()
+	return fmt.Errorf("login failed: %s", err)
+}
+
+// Re-logs in a session in the background once nothing is using it, the pool skips it meanwhile.
+func reloginTwitterSession(session *twitterSession) {
+	twitterSessionMutex.Lock()
+	if session.LoggingIn || session.Username == "" {
+		twitterSessionMutex.Unlock()
+		return
+	}
+	session.LoggingIn = true
+	twitterSessionMutex.Unlock()
+
+	session.inUse.Lock()
+	err := loginTwitterSession(session)
+	session.inUse.Unlock()
+
+	twitterSessionMutex.Lock()
+	alert := ""
+	if err != nil && !session.Alerted {
+		alert = fmt.Sprintf("⚠️ Twitter (X) account `@%s` couldn't log in after %d attempts and needs attention: `%s`",
+			session.Username, twitterLoginAttempts, err)
+		session.Alerted = true
+	} else if err == nil && session.Alerted {
+		alert = fmt.Sprintf("✅ Twitter (X) account `@%s` is logged in again.", session.Username)
+		session.Alerted = false
+	}
+	session.LoggingIn = false
+	twitterSessionMutex.Unlock()
+	if alert != "" {
+		notifyAdmins(alert)
+	}
+	updateTwitterConnected()
+}
+
+func (session *twitterSession) setConnected(connected bool) {
+	twitterSessionMutex.Lock()
+	session.Connected = connected
+	twitterSessionMutex.Unlock()
+	updateTwitterConnected()
+}
+
+func updateTwitterConnected() {
+	twitterSessionMutex.Lock()
+	defer twitterSessionMutex.Unlock()
+	connected := false
+	for _, session := range twitterSessions {
+		if session.Connected {
+			connected = true
+		}
+	}
+	twitterConnected = connected
+}
+
+// Takes the next usable session round robin, skipping ones that are busy, rate limited or logged out.
+// Call release() on it when done.
+func getTwitterSession() (*twitterSession, error) {
+	twitterSessionMutex.Lock()
+	defer twitterSessionMutex.Unlock()
+	if len(twitterSessions) == 0 {
+		return nil, errors.New("twitter (x) scraper is not initialized")
+	}
+	var available time.Time
+	for k := 0; k < len(twitterSessions); k++ {
+		index := (twitterSessionNext + k) % len(twitterSessions)
+		session := twitterSessions[index]
+		if session.LoggingIn {
+			continue
+		}
+		if session.Username != "" && !session.Connected {
+			if time.Since(session.LastLogin) > twitterReloginInterval {
+				go reloginTwitterSession(session)
+			}
+			continue
+		}
+		if time.Now().Before(session.CooldownUntil) {
+			if available.IsZero() || session.CooldownUntil.Before(available) {
+				available = session.CooldownUntil
+			}
+			continue
+		}
+		if !session.inUse.TryLock() {
+			continue
+		}
+		twitterSessionNext = index + 1
+		session.LastUsed = time.Now()
+		return session, nil
+	}
+	if !available.IsZero() {
+		return nil, fmt.Errorf("all twitter (x) sessions are rate limited, next one is available %s", humanize.Time(available))
+	}
+	return nil, errors.New("no twitter (x) sessions are available")
+}
+
+func (session *twitterSession) release() {
+	session.inUse.Unlock()
+}
+
+// Cools down a rate limited session, or re-logs in one that was signed out.
+func (session *twitterSession) checkError(err error) {
+	if err == nil {
+		return
+	}
+	if isTwitterRateLimitError(err) {
+		cooldownUntil := time.Now().Add(getTwitterSessionCooldown())
+		twitterSessionMutex.Lock()
+		session.CooldownUntil = cooldownUntil
+		twitterSessionMutex.Unlock()
+		log.Println(color.HiYellowString("Twitter (X) session @%s is rate limited, cooling down until %s...",
+			session.Username, cooldownUntil.Format("15:04")))
+	} else if session.Username != "" && isTwitterAuthError(err) {
+		session.setConnected(false)
+		go reloginTwitterSession(session)
+	}
+}
+
+var (
+	// The scraper reports API failures as "response status 429 Too Many Requests: ..."
+	regexTwitterRateLimit = regexp.MustCompile(`(?i)\bstatus 429\b|rate limit`)
+	regexTwitterAuth      = regexp.MustCompile(`(?i)\bstatus 40[13]\b|\bauth error\b|invalid credentials|not logged in|could not authenticate`)
+)
+
+func isTwitterRateLimitError(err error) bool {
+	return regexTwitterRateLimit.MatchString(err.Error())
+}
+
+func isTwitterAuthError(err error) bool {
+	return regexTwitterAuth.MatchString(err.Error())
+}
+
+// Runs a request on a session from the pool, moving on to the next session when one is rate limited part way.
+func withTwitterSession(proxy string, request func(session *twitterSession) error) error {
+	twitterSessionMutex.Lock()
+	attempts := len(twitterSessions)
+	twitterSessionMutex.Unlock()
+	var err error
+	for attempt := 0; attempt < attempts || attempt == 0; attempt++ {
+		session, sessionErr := getTwitterSession()
+		if sessionErr != nil {
+			if err != nil {
+				return fmt.Errorf("%s, then %s", err, sessionErr)
+			}
+			return sessionErr
+		}
+		if err = session.setProxy(proxy); err != nil {
+			session.release()
+			return fmt.Errorf("failed to set proxy: %s", err)
+		}
+		err = request(session)
 		if err != nil {
+			if isProxyError(err) {
+				markProxyFailed(proxy)
+			}
+			session.checkError(err)
+		}
+		session.release()
+		if err == nil || !isTwitterRateLimitError(err) {
 			return err
 		}
-		f, err := os.Create(pathDataCookiesTwitter)
+	}
+	return err
+}
+
+// Checks every logged in session still works, logging in again the ones that don't and saving
+// fresh cookies for the ones that do.
+func checkTwitterSessions() {
+	twitterSessionMutex.Lock()
+	sessions := append([]*twitterSession{}, twitterSessions...)
+	twitterSessionMutex.Unlock()
+	for _, session := range sessions {
+		twitterSessionMutex.Lock()
+		loggingIn, connected := session.LoggingIn, session.Connected
+		twitterSessionMutex.Unlock()
+		if session.Username == "" || loggingIn {
+			continue
+		}
+		if !connected {
+			reloginTwitterSession(session)
+			continue
+		}
+		if !session.inUse.TryLock() { // in use, checked next time
+			continue
+		}
+		var err error
+		if !session.Scraper.IsLoggedIn() {
+			err = errors.New("not logged in")
+		} else if _, err = session.Scraper.GetProfile("x"); err != nil && isTwitterRateLimitError(err) {
+			session.checkError(err)
+			err = nil // still logged in, just resting
+		}
+		if err == nil {
+			if err := session.exportCookies(); err != nil {
+				log.Println(color.HiRedString("Failed to save Twitter (X) cookies for @%s: %s", session.Username, err))
+			}
+		}
+		session.inUse.Unlock()
 		if err != nil {
-			return err
+			log.Println(color.HiYellowString("Twitter (X) session @%s check failed, logging in again: %s", session.Username, err))
+			session.setConnected(false)
+			reloginTwitterSession(session)
 		}
-		f.Write(js)
+	}
+}
+
+// Switches the scraper's proxy, only replacing its transport when the proxy actually changes.
+func (session *twitterSession) setProxy(proxy string) error {
+	if proxy == session.Proxy {
 		return nil
 	}
+	if proxy != "" {
+		proxyURL, err := parseProxyURL(proxy)
+		if err != nil {
+			return err
+		}
+		if proxyURL.Scheme == "socks5" && proxyURL.User != nil {
+			return errors.New("the twitter scraper doesn't support socks5 proxy credentials")
+		}
+		proxy = proxyURL.String()
+	}
+	if err := session.Scraper.SetProxy(proxy); err != nil {
+		return err
+	}
+	session.Proxy = proxy
+	return nil
+}
 
-	twitterScraper = twitterscraper.New()
+func (session *twitterSession) importCookies() error {
+	f, err := os.Open(session.CookiePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var cookies []*http.Cookie
+	if err = json.NewDecoder(f).Decode(&cookies); err != nil {
+		return err
+	}
+	session.Scraper.SetCookies(cookies)
+	session.Scraper.IsLoggedIn()
+	_, err = session.Scraper.GetProfile("x")
+	return err
+}
 
-	if twitterUsername != "" &&
-		twitterPassword != "" {
-		log.Println(l.LogI(true, "Connecting to Twitter (X)..."))
+func (session *twitterSession) exportCookies() error {
+	js, err := json.Marshal(session.Scraper.GetCookies())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(session.CookiePath, js, 0644)
+}
 
-		twitterLoginCount := 0
-	do_twitter_login:
-		twitterLoginCount++
-		if twitterLoginCount > 1 {
-			time.Sleep(3 * time.Second)
+func exportTwitterCookies() error {
+	var lastErr error
+	twitterSessionMutex.Lock()
+	sessions := append([]*twitterSession{}, twitterSessions...)
+	twitterSessionMutex.Unlock()
+	for _, session := range sessions {
+		twitterSessionMutex.Lock()
+		connected := session.Connected
+		twitterSessionMutex.Unlock()
+		if connected {
+			if err := session.exportCookies(); err != nil {
+				lastErr = fmt.Errorf("@%s: %s", session.Username, err)
+			}
 		}
+	}
+	return lastErr
+}
 
-		if twitterImport() != nil {
-			twitterScraper.ClearCookies()
-			if err := twitterScraper.Login(twitterUsername, twitterPassword); err != nil {
-				log.Println(l.SetFlag(&lError).Log("Login Error: %s", err.Error()))
-				l.ClearFlag()
-				if twitterLoginCount <= 3 {
-					goto do_twitter_login
-				} else { // Login Failed
-					log.Println(l.SetFlag(&lError).Log(
-						"Failed to login to Twitter (X), the bot will not fetch this media..."))
-					l.ClearFlag()
-				}
-			} else { // Connected!
-				twitterConnected = true
-				defer twitterExport()
-				if twitterScraper.IsLoggedIn() {
-					log.Println(l.LogCI(color.HiMagentaString, true, "Connected to @%s via new login", twitterUsername))
-				} else {
-					log.Println(l.SetFlag(&lWarning).Log(
-						"Scraper login seemed successful but bot is not logged in, Twitter (X) parsing may not work..."))
-					l.ClearFlag()
-				}
+// Profile lookup through the session pool, for modules borrowing Twitter appearance.
+func getTwitterProfile(handle string) (twitterscraper.Profile, error) {
+	var profile twitterscraper.Profile
+	err := withTwitterSession(resolveProxy("", twitterConfig.Proxy, twitterConfig.ProxyPool), func(session *twitterSession) error {
+		var err error
+		profile, err = session.Scraper.GetProfile(handle)
+		return err
+	})
+	return profile, err
+}
+
+// Reads a timeline to the end, stopping at the first error so a rate limited session can hand over to another.
+func (session *twitterSession) getTweets(handle string, count int) ([]*twitterscraper.TweetResult, error) {
+	ctx, cancel := context.WithCancel(ctxRoot)
+	defer cancel()
+	results := session.Scraper.GetTweets(ctx, handle, count)
+	defer func() {
+		go func() { // the scraper blocks sending the rest otherwise
+			for range results {
 			}
-		} else {
-			log.Println(l.LogCI(color.HiMagentaString, true, "Connected to @%s via cache", twitterUsername))
-			twitterConnected = true
+		}()
+	}()
+	var tweets []*twitterscraper.TweetResult
+	for tweet := range results {
+		if tweet.Error != nil {
+			return tweets, tweet.Error
 		}
-	} else {
-		log.Println(l.LogI(true, "Twitter (X) credentials missing, the bot will not fetch this media..."))
+		tweets = append(tweets, tweet)
 	}
-
-	return nil
+	return tweets, nil
 }
 
 func handleTwitterAcc(account configModuleTwitterAcc) error {
@@ -226,10 +626,27 @@ func handleTwitterAcc(account configModuleTwitterAcc) error {
 		excludeReplies = *account.ExcludeReplies
 	}
 
-	// User Info
-	user, err := twitterScraper.GetProfile(account.Handle)
+	// User Info & Timeline
+	var user twitterscraper.Profile
+	var tweets []*twitterscraper.TweetResult
+	proxy := resolveProxy(account.Proxy, twitterConfig.Proxy, twitterConfig.ProxyPool)
+	err := withTwitterSession(proxy, func(session *twitterSession) error {
+		var err error
+		if user, err = session.Scraper.GetProfile(account.Handle); err != nil {
+			return fmt.Errorf("failed to fetch twitter user @%s: %s", account.Handle, err.Error())
+		}
+		if tweets, err = session.getTweets(account.Handle, 50); err != nil {
+			if isTwitterRateLimitError(err) {
+				return err // start over on another session, what was sent already is skipped
+			}
+			session.checkError(err)
+			log.Println(l.SetFlag(&lError).Log("Error fetching tweets: %s", err.Error()))
+			l.ClearFlag()
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("[ID:%s] failed to fetch twitter user @%s", account.Handle, err.Error())
+		return fmt.Errorf("[ID:%s] %s", account.Handle, err.Error())
 	}
 
 	// User Appearance Vars
@@ -253,19 +670,24 @@ func handleTwitterAcc(account configModuleTwitterAcc) error {
 		userColor = account.Color
 	}
 
-	// User Timeline
-	tweets := twitterScraper.GetTweets(context.Background(), account.Handle, 50)
-
 	// FOREACH Tweet
-	for tweet := range tweets { // because iterating a channel, len returns 0
+	var tweetTimes []time.Time
+	for _, tweet := range tweets {
 		if tweet.ID == "" {
 			continue
 		}
+		tweetTimes = append(tweetTimes, tweet.TimeParsed)
 		// Tweet Vars
 		//TODO: calc & check timespan
 		//tweetPathS := handle + "/" + tweet.IdStr
 		tweetPath := handle + "/status/" + tweet.ID
 		tweetLink := "https://twitter.com/" + tweetPath
+		tweetRef := canonicalTweetURL(tweet.ID)
+		tweetItem := refItem{
+			Ref:    tweetRef,
+			URL:    tweetRef,
+			Source: account.Name,
+		}
 		/*tweetParent := tweet
 		if tweet.RetweetedStatus != nil {
 			if tweet.RetweetedStatus.QuotedStatus != nil { // RT'd Quote
@@ -447,12 +869,25 @@ func handleTwitterAcc(account configModuleTwitterAcc) error {
 		if vibeCheck { //TODO: AND meets days old criteria
 			for _, destination := range account.Destinations {
 				sendAttempts := 0
-				if !refCheckSentToChannel(tweetLink, destination.Channel) {
+				if !refCheckSentToChannel(tweetRef, destination.Channel) {
+					// Same tweet already sent by another feed? (e.g. an RSS feed linking to it)
+					if suppressed, err := suppressDuplicate(tweetItem, destination.Channel, moduleNameTwitterAccounts); suppressed {
+						if err != nil {
+							log.Println(l.SetFlag(&lError).Log(
+								"Error listing %s as also reported on the original post: %s", account.Name, err.Error()))
+							l.ClearFlag()
+						}
+						if generalConfig.Debug2 {
+							log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- DUPLICATE SUPPRESSED %s to %s", tweetLink, destination.Channel))
+							l.ClearFlag()
+						}
+						continue
+					}
 					// SEND
 				resend:
 					sendAttempts++
 					webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", destination.Channel, tweetLink)
-					err = sendWebhook(destination.Channel, tweetLink, discordwebhook.Message{
+					_, err = sendWebhookItem(destination.Channel, tweetItem, discordwebhook.Message{
 						Username:  &username,
 						AvatarUrl: &avatar,
 						Content:   &tweetLink,
@@ -486,9 +921,12 @@ func handleTwitterAcc(account configModuleTwitterAcc) error {
 								"%s encountered an error while sending: %s", webhookInfo, err.Error()))
 							l.ClearFlag()
 						}
-					} else if generalConfig.Debug2 {
-						log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", tweetLink, destination.Channel))
-						l.ClearFlag()
+					} else {
+						markFeedNewItem(feedTwitterAccount, account.Name)
+						if generalConfig.Debug2 {
+							log.Println(l.SetFlag(&lDebug2).LogI(true, "SENT %s to %s", tweetLink, destination.Channel))
+							l.ClearFlag()
+						}
 					}
 				} else if generalConfig.Debug2 {
 					log.Println(l.SetFlag(&lDebug2).LogCI(color.BlueString, true, "- ALREADY SENT %s to %s", tweetLink, destination.Channel))
@@ -498,6 +936,8 @@ func handleTwitterAcc(account configModuleTwitterAcc) error {
 		}
 	}
 
+	setFeedHints(feedTwitterAccount, account.Name, feedScheduleHints{ItemTimes: tweetTimes})
+
 	if generalConfig.Debug {
 		waitMins := twitterConfig.WaitMins
 		if account.WaitMins != nil {
@@ -533,6 +973,12 @@ func handleTwitterAccCmdOpts(config *configModuleTwitterAcc,
 		val := int(opt.IntValue())
 		config.WaitMins = &val
 	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
 	// Optional Vars - Appearance
 	if opt, ok := optionMap["username"]; ok {
 		config.Username = opt.StringValue()
@@ -572,6 +1018,40 @@ func handleTwitterAccCmdOpts(config *configModuleTwitterAcc,
 	return nil
 }
 
+func newTwitterAccFeedThread(account configModuleTwitterAcc) feedThread {
+	thread := feedThread{
+		Group:       feedTwitterAccount,
+		Name:        account.Name,
+		Ref:         account.Handle,
+		Config:      account,
+		WaitMins:    twitterConfig.WaitMins,
+		Adaptive:    twitterConfig.Adaptive,
+		MinWaitMins: twitterConfig.MinWaitMins,
+		MaxWaitMins: twitterConfig.MaxWaitMins,
+	}
+	if account.WaitMins != nil {
+		thread.WaitMins = *account.WaitMins
+	}
+	if account.Adaptive != nil {
+		thread.Adaptive = *account.Adaptive
+	}
+	if account.MinWaitMins != nil {
+		thread.MinWaitMins = *account.MinWaitMins
+	}
+	if account.MaxWaitMins != nil {
+		thread.MaxWaitMins = *account.MaxWaitMins
+	}
+	if account.Enabled != nil {
+		thread.Paused = !*account.Enabled
+	}
+	if account.Schedule != "" {
+		setFeedSchedule(&thread, account.Schedule)
+	} else {
+		setFeedSchedule(&thread, twitterConfig.Schedule)
+	}
+	return thread
+}
+
 func getTwitterAccConfigIndex(name string) int {
 	for k, feed := range twitterConfig.Accounts {
 		if strings.EqualFold(name, feed.Name) {
@@ -618,3 +1098,586 @@ func deleteTwitterAccConfig(name string) error {
 	}
 	return errors.New("twitter account config does not exist")
 }
+
+func setTwitterAccConfigEnabled(name string, enabled bool) error {
+	config := getTwitterAccConfig(name)
+	if config == nil {
+		return errors.New("twitter account config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedTwitterAccount, *config)
+	return nil
+}
+
+//#region Trends
+
+type twitterTrend struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	TweetVolume int    `json:"tweet_volume"` // 0 when twitter doesn't give one
+}
+
+type twitterTrendsPlace struct {
+	Trends    []twitterTrend `json:"trends"`
+	Locations []struct {
+		Name  string `json:"name"`
+		WOEID int    `json:"woeid"`
+	} `json:"locations"`
+}
+
+type twitterTrendsLocation struct {
+	Name        string `json:"name"`
+	Country     string `json:"country"`
+	CountryCode string `json:"countryCode"`
+	WOEID       int    `json:"woeid"`
+}
+
+// Last posted list and the keywords currently trending, saved as the feed's cursor.
+type twitterTrendsState struct {
+	Trends   []string          `json:"trends"`
+	Trending map[string]string `json:"trending,omitempty"` // keyword to the trend it matched
+	Pending  map[string]string `json:"pending,omitempty"`  // keyword to the ref of an alert not every destination got
+}
+
+var (
+	twitterTrendsLocations      []twitterTrendsLocation // from trends/available, for place names
+	twitterTrendsLocationsMutex sync.Mutex
+)
+
+func twitterAPIGet(session *twitterSession, link string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctxRoot, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	return session.Scraper.RequestAPI(req, v)
+}
+
+// Resolves a WOEID or place name ("Japan", "London", "gb") to a WOEID, 1 is worldwide.
+func getTwitterTrendsWOEID(session *twitterSession, location string) (int, error) {
+	location = strings.TrimSpace(location)
+	if location == "" || strings.EqualFold(location, "worldwide") {
+		return 1, nil
+	}
+	if woeid, err := strconv.Atoi(location); err == nil {
+		return woeid, nil
+	}
+	twitterTrendsLocationsMutex.Lock()
+	defer twitterTrendsLocationsMutex.Unlock()
+	if len(twitterTrendsLocations) == 0 {
+		if err := twitterAPIGet(session, "https://api.twitter.com/1.1/trends/available.json", &twitterTrendsLocations); err != nil {
+			return 0, fmt.Errorf("failed to fetch trend locations: %s", err)
+		}
+	}
+	for _, place := range twitterTrendsLocations {
+		if strings.EqualFold(place.Name, location) {
+			return place.WOEID, nil
+		}
+	}
+	for _, place := range twitterTrendsLocations { // country code, the country's own entry has no parent name
+		if strings.EqualFold(place.CountryCode, location) && strings.EqualFold(place.Name, place.Country) {
+			return place.WOEID, nil
+		}
+	}
+	return 0, fmt.Errorf("no trends location named \"%s\"", location)
+}
+
+// Trends for a location, ranked, and the location's name. Worldwide falls back to the scraper's own trends,
+// without volumes, if the place endpoint isn't available to the session.
+func getTwitterTrends(session *twitterSession, woeid int) ([]twitterTrend, string, error) {
+	var places []twitterTrendsPlace
+	err := twitterAPIGet(session, fmt.Sprintf("https://api.twitter.com/1.1/trends/place.json?id=%d", woeid), &places)
+	if err == nil && len(places) > 0 {
+		name := ""
+		if len(places[0].Locations) > 0 {
+			name = places[0].Locations[0].Name
+		}
+		return places[0].Trends, name, nil
+	}
+	if err == nil {
+		err = errors.New("no trends returned")
+	}
+	if woeid != 1 || isTwitterRateLimitError(err) {
+		return nil, "", err
+	}
+	names, fallbackErr := session.Scraper.GetTrends()
+	if fallbackErr != nil {
+		return nil, "", err
+	}
+	var trends []twitterTrend
+	for _, name := range names {
+		trends = append(trends, twitterTrend{Name: name})
+	}
+	return trends, "", nil
+}
+
+func getTwitterTrendLink(trend twitterTrend) string {
+	if trend.URL != "" {
+		return strings.Replace(trend.URL, "http://", "https://", 1)
+	}
+	return "https://twitter.com/search?q=" + url.QueryEscape(trend.Name)
+}
+
+func formatTweetVolume(volume int) string {
+	switch {
+	case volume >= 1000000:
+		return fmt.Sprintf("%.1fM", float64(volume)/1000000)
+	case volume >= 1000:
+		return fmt.Sprintf("%.1fK", float64(volume)/1000)
+	}
+	return fmt.Sprint(volume)
+}
+
+// A watched keyword, lowercased without the #, with its whole word pattern.
+type twitterTrendKeyword struct {
+	Keyword string
+	Words   *regexp.Regexp
+}
+
+func compileTwitterTrendKeywords(keywords []string) []twitterTrendKeyword {
+	var compiled []twitterTrendKeyword
+	for _, keyword := range keywords {
+		if keyword = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(keyword), "#")); keyword == "" {
+			continue
+		}
+		compiled = append(compiled, twitterTrendKeyword{
+			Keyword: keyword,
+			Words:   regexp.MustCompile(`(^|[^\pL\pN])` + regexp.QuoteMeta(keyword) + `($|[^\pL\pN])`),
+		})
+	}
+	return compiled
+}
+
+// Whether a hashtag's words start at index i, going by CamelCase, digits and underscores (#NASAMoon2024_Live).
+func isHashtagWordBoundary(tag []rune, i int) bool {
+	if i <= 0 || i >= len(tag) {
+		return true
+	}
+	prev, next := tag[i-1], tag[i]
+	switch {
+	case prev == '_' || next == '_':
+		return true
+	case unicode.IsDigit(prev) != unicode.IsDigit(next):
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(next):
+		return true
+	case unicode.IsUpper(prev) && unicode.IsUpper(next) && i+1 < len(tag) && unicode.IsLower(tag[i+1]): // end of an acronym
+		return true
+	}
+	return false
+}
+
+// Watched keyword a trend matches as whole words, ignoring case. Hashtags also match by prefix, since
+// they run words together, as long as the keyword ends on a word (#GolangConf has "golang", #GoodMorning
+// doesn't have "go").
+func matchTwitterTrendKeyword(keywords []twitterTrendKeyword, trend string) string {
+	hashtag := []rune(strings.TrimPrefix(trend, "#"))
+	isHashtag := strings.HasPrefix(trend, "#")
+	trend = strings.ToLower(string(hashtag))
+	for _, keyword := range keywords {
+		if isHashtag {
+			prefix := []rune(strings.ReplaceAll(keyword.Keyword, " ", ""))
+			if len(prefix) <= len(hashtag) && strings.EqualFold(string(hashtag[:len(prefix)]), string(prefix)) &&
+				isHashtagWordBoundary(hashtag, len(prefix)) {
+				return keyword.Keyword
+			}
+		}
+		if keyword.Words.MatchString(trend) {
+			return keyword.Keyword
+		}
+	}
+	return ""
+}
+
+func getTwitterTrendsTop(feed configModuleTwitterTrends) int {
+	top := 10
+	if feed.Top != nil && *feed.Top > 0 {
+		top = *feed.Top
+	}
+	if top > 50 {
+		top = 50
+	}
+	return top
+}
+
+func handleTwitterTrends(feed configModuleTwitterTrends) error {
+	l := logInstructions{
+		Location: fmt.Sprintf("handleTwitterTrends(%s): ", feed.Name),
+		Task:     "",
+		Inline:   false,
+		Color:    color.BlueString,
+	}
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED STARTING ... Twitter Trends \"%s\"", feed.Name))
+		l.ClearFlag()
+	}
+
+	proxy := resolveProxy(feed.Proxy, twitterConfig.Proxy, twitterConfig.ProxyPool)
+	var woeid int
+	var trends []twitterTrend
+	var locationName string
+	err := withTwitterSession(proxy, func(session *twitterSession) error {
+		var err error
+		if woeid, err = getTwitterTrendsWOEID(session, feed.Location); err != nil {
+			return err
+		}
+		if trends, locationName, err = getTwitterTrends(session, woeid); err != nil {
+			return fmt.Errorf("failed to fetch trends: %s", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[%s] %s", feed.Location, err.Error())
+	}
+
+	var state twitterTrendsState
+	cursor := feedCursorGet(feedTwitterTrends, feed.Name)
+	firstRun := cursor == ""
+	if !firstRun {
+		json.Unmarshal([]byte(cursor), &state)
+	}
+	if state.Trending == nil {
+		state.Trending = make(map[string]string)
+	}
+	if state.Pending == nil {
+		state.Pending = make(map[string]string)
+	}
+	previous := make(map[string]bool)
+	for _, name := range state.Trends {
+		previous[strings.ToLower(name)] = true
+	}
+
+	// Ranked list, posted when a trend enters or leaves the top, reordering alone isn't worth a post
+	top := trends
+	if len(top) > getTwitterTrendsTop(feed) {
+		top = top[:getTwitterTrendsTop(feed)]
+	}
+	var topNames []string
+	changed := len(top) != len(state.Trends)
+	for _, trend := range top {
+		topNames = append(topNames, trend.Name)
+		if !previous[strings.ToLower(trend.Name)] {
+			changed = true
+		}
+	}
+
+	if locationName == "" {
+		locationName = feed.Location
+		if locationName == "" || woeid == 1 {
+			locationName = "Worldwide"
+		}
+	}
+	username := "Twitter Trends"
+	if feed.Username != "" {
+		username = feed.Username
+	}
+	avatar := twitterLogo
+	if feed.Avatar != "" {
+		avatar = feed.Avatar
+	}
+	trendsColor := projectColor           // default to project
+	if generalConfig.DefaultColor != "" { // override with general if present
+		trendsColor = generalConfig.DefaultColor
+	}
+	if twitterConfig.DefaultColor != "" { // override with twitter if present
+		trendsColor = twitterConfig.DefaultColor
+	}
+	if feed.Color != "" { // override with specific if present
+		trendsColor = feed.Color
+	}
+	embedColor, err := hexdec(trendsColor)
+	if err != nil {
+		log.Println(l.SetFlag(&lError).Log("Error parsing color: " + err.Error()))
+		l.ClearFlag()
+	}
+	// Skips channels that already got the ref, so a post retried after a failed send isn't repeated
+	send := func(ref string, title string, message discordwebhook.Message, channel string) bool {
+		if refCheckSentToChannel(ref, channel) {
+			return true
+		}
+		sendAttempts := 0
+	resend:
+		sendAttempts++
+		webhookInfo := fmt.Sprintf("WEBHOOK to %s (\"%s\")", channel, title)
+		sendItem := refItem{Ref: ref, Title: title, Source: feed.Name}
+		if _, err := sendWebhookItem(channel, sendItem, message, moduleNameTwitterTrends); err != nil {
+			if strings.Contains(err.Error(), "resource is being rate limited") && sendAttempts < 5 {
+				log.Println(l.SetFlag(&lError).Log(
+					"%s is being rate limited... delaying 3 seconds and trying again...", webhookInfo))
+				l.ClearFlag()
+				time.Sleep(3 * time.Second)
+				goto resend
+			}
+			log.Println(l.SetFlag(&lError).Log(
+				"%s encountered an error while sending: %s", webhookInfo, err.Error()))
+			l.ClearFlag()
+			return false
+		}
+		markFeedNewItem(feedTwitterTrends, feed.Name)
+		return true
+	}
+
+	if changed && len(top) > 0 {
+		var lines []string
+		for k, trend := range top {
+			line := fmt.Sprintf("`%2d.` [%s](%s)", k+1, trend.Name, getTwitterTrendLink(trend))
+			if !firstRun && !previous[strings.ToLower(trend.Name)] {
+				line = fmt.Sprintf("`%2d.` 🆕 **[%s](%s)**", k+1, trend.Name, getTwitterTrendLink(trend))
+			}
+			if trend.TweetVolume > 0 {
+				line += fmt.Sprintf(" · %s posts", formatTweetVolume(trend.TweetVolume))
+			}
+			lines = append(lines, line)
+		}
+		title := fmt.Sprintf("Trending · %s", locationName)
+		description := truncateText(strings.Join(lines, "\n"), 4000)
+		footerText := fmt.Sprintf("Top %d", len(top))
+		embed := discordwebhook.Embed{
+			Title:       &title,
+			Description: &description,
+			Color:       &embedColor,
+			Footer: &discordwebhook.Footer{
+				Text:    &footerText,
+				IconUrl: &twitterLogo,
+			},
+		}
+		message := discordwebhook.Message{
+			Username:  &username,
+			AvatarUrl: &avatar,
+			Embeds:    &[]discordwebhook.Embed{embed},
+		}
+		// Same change gives the same ref, so a retry only goes to the destinations that missed it
+		hasher := fnv.New64a()
+		hasher.Write([]byte(strings.ToLower(strings.Join(state.Trends, "\n") + "\x00" + strings.Join(topNames, "\n"))))
+		ref := fmt.Sprintf("twitter-trends://%d/%x", woeid, hasher.Sum64())
+		delivered := true
+		for _, destination := range feed.Destinations {
+			if !send(ref, title, message, destination.Channel) {
+				delivered = false
+			}
+		}
+		if delivered { // otherwise the change is posted again next run
+			state.Trends = topNames
+		}
+	}
+
+	// Keyword alerts, checked against every trend and only once per stretch of trending
+	keywords := compileTwitterTrendKeywords(feed.Keywords)
+	stillTrending := make(map[string]bool)
+	for k, trend := range trends {
+		keyword := matchTwitterTrendKeyword(keywords, trend.Name)
+		if keyword == "" || stillTrending[keyword] {
+			continue
+		}
+		stillTrending[keyword] = true
+		if _, alerted := state.Trending[keyword]; alerted {
+			continue
+		}
+		if firstRun {
+			state.Trending[keyword] = trend.Name
+			continue
+		}
+		content := fmt.Sprintf("📈 **%s** is trending in %s at #%d", trend.Name, locationName, k+1)
+		if trend.TweetVolume > 0 {
+			content += fmt.Sprintf(" with %s posts", formatTweetVolume(trend.TweetVolume))
+		}
+		content += fmt.Sprintf(" (watching `%s`)\n<%s>", keyword, getTwitterTrendLink(trend))
+		ref, retry := state.Pending[keyword]
+		if !retry {
+			ref = fmt.Sprintf("twitter-trends://%d/keyword/%s/%d", woeid, url.PathEscape(keyword), time.Now().Unix())
+		}
+		delivered := true
+		for _, destination := range feed.Destinations {
+			destContent := content
+			if mentions := getDestinationMentions(destination); mentions != "" {
+				destContent = mentions + "\n" + content
+			}
+			message := discordwebhook.Message{
+				Username:  &username,
+				AvatarUrl: &avatar,
+				Content:   &destContent,
+			}
+			if !send(ref, trend.Name, message, destination.Channel) {
+				delivered = false
+			}
+		}
+		if delivered {
+			state.Trending[keyword] = trend.Name
+			delete(state.Pending, keyword)
+		} else { // tried again next run with the same ref
+			state.Pending[keyword] = ref
+		}
+	}
+	for keyword := range state.Trending {
+		if !stillTrending[keyword] {
+			delete(state.Trending, keyword)
+		}
+	}
+	for keyword := range state.Pending {
+		if !stillTrending[keyword] {
+			delete(state.Pending, keyword)
+		}
+	}
+	if stateJson, err := json.Marshal(state); err == nil && string(stateJson) != cursor {
+		feedCursorSet(feedTwitterTrends, feed.Name, string(stateJson))
+	}
+
+	if generalConfig.Debug {
+		log.Println(l.SetFlag(&lDebug).LogI(true, "FEED COMPLETED ... Twitter Trends %s", feed.Name))
+		l.ClearFlag()
+	}
+
+	return nil
+}
+
+func handleTwitterTrendsCmdOpts(config *configModuleTwitterTrends,
+	optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	s *discordgo.Session, i *discordgo.InteractionCreate) error {
+
+	// Optional Vars
+	if opt, ok := optionMap["change-location"]; ok {
+		config.Location = opt.StringValue()
+	}
+	if opt, ok := optionMap["tag"]; ok {
+		tagged := opt.UserValue(s)
+		if tagged != nil {
+			destClone := config.Destinations
+			for key, destination := range destClone {
+				if destination.Channel == i.ChannelID {
+					config.Destinations[key].Tags = []string{tagged.ID}
+				}
+			}
+		}
+	}
+	if opt, ok := optionMap["role"]; ok {
+		role := opt.RoleValue(nil, "")
+		destClone := config.Destinations
+		for key, destination := range destClone {
+			if destination.Channel == i.ChannelID {
+				config.Destinations[key].Roles = []string{role.ID}
+			}
+		}
+	}
+	if opt, ok := optionMap["wait"]; ok {
+		val := int(opt.IntValue())
+		config.WaitMins = &val
+	}
+	if opt, ok := optionMap["schedule"]; ok {
+		if _, err := parseFeedSchedule(opt.StringValue()); err != nil {
+			return fmt.Errorf("invalid schedule: %s", err)
+		}
+		config.Schedule = opt.StringValue()
+	}
+	// Optional Vars - Appearance
+	if opt, ok := optionMap["username"]; ok {
+		config.Username = opt.StringValue()
+	}
+	if opt, ok := optionMap["avatar"]; ok {
+		config.Avatar = opt.StringValue()
+	}
+	if opt, ok := optionMap["color"]; ok {
+		config.Color = opt.StringValue()
+	}
+	// Optional Vars - Rules
+	if opt, ok := optionMap["top"]; ok {
+		val := int(opt.IntValue())
+		if val < 1 || val > 50 {
+			return errors.New("top must be between 1 and 50")
+		}
+		config.Top = &val
+	}
+	if opt, ok := optionMap["keywords"]; ok {
+		var keywords []string
+		for _, keyword := range strings.Split(opt.StringValue(), ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		config.Keywords = keywords
+	}
+	return nil
+}
+
+func newTwitterTrendsFeedThread(feed configModuleTwitterTrends) feedThread {
+	ref := feed.Location
+	if strings.TrimSpace(ref) == "" { // feeds without a ref never run
+		ref = "worldwide"
+	}
+	thread := feedThread{
+		Group:    feedTwitterTrends,
+		Name:     feed.Name,
+		Ref:      ref,
+		Config:   feed,
+		WaitMins: twitterConfig.WaitMins,
+	}
+	if feed.WaitMins != nil {
+		thread.WaitMins = *feed.WaitMins
+	}
+	if feed.Enabled != nil {
+		thread.Paused = !*feed.Enabled
+	}
+	if feed.Schedule != "" {
+		setFeedSchedule(&thread, feed.Schedule)
+	} else {
+		setFeedSchedule(&thread, twitterConfig.Schedule)
+	}
+	return thread
+}
+
+func getTwitterTrendsConfigIndex(name string) int {
+	for k, feed := range twitterConfig.Trends {
+		if strings.EqualFold(name, feed.Name) {
+			return k
+		}
+	}
+	return -1
+}
+
+func getTwitterTrendsConfig(name string) *configModuleTwitterTrends {
+	i := getTwitterTrendsConfigIndex(name)
+	if i == -1 {
+		return nil
+	} else {
+		return &twitterConfig.Trends[i]
+	}
+}
+
+func existsTwitterTrendsConfig(name string) bool {
+	return getTwitterTrendsConfig(name) != nil
+}
+
+func updateTwitterTrendsConfig(name string, config configModuleTwitterTrends) bool {
+	feedClone := twitterConfig.Trends
+	for key, feed := range feedClone {
+		if strings.EqualFold(name, feed.Name) {
+			twitterConfig.Trends[key] = config
+			return true
+		}
+	}
+	return false
+}
+
+func deleteTwitterTrendsConfig(name string) error {
+	index := getTwitterTrendsConfigIndex(name)
+	if index != -1 {
+		// Remove from loaded config
+		twitterConfig.Trends = append(twitterConfig.Trends[:index], twitterConfig.Trends[index+1:]...)
+		// Remove from live feeds
+		if !deleteFeed(name, feedTwitterTrends) {
+			return errors.New("failed to delete from live feeds")
+		}
+		return nil
+	}
+	return errors.New("twitter trends config does not exist")
+}
+
+func setTwitterTrendsConfigEnabled(name string, enabled bool) error {
+	config := getTwitterTrendsConfig(name)
+	if config == nil {
+		return errors.New("twitter trends config does not exist")
+	}
+	config.Enabled = &enabled
+	updateFeedConfig(config.Name, feedTwitterTrends, *config)
+	return nil
+}
+
+//#endregion
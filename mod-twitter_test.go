@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestMatchTwitterTrendKeyword(t *testing.T) {
+	tests := []struct {
+		keywords []string
+		trend    string
+		want     string
+	}{
+		{[]string{"go"}, "#GoodMorning", ""},
+		{[]string{"go"}, "#goodmorning", ""},
+		{[]string{"go"}, "#GOODMORNING", ""},
+		{[]string{"golang"}, "#GolangConf", "golang"},
+		{[]string{"#Golang"}, "#golang", "golang"},
+		{[]string{"go"}, "#Go2026", "go"},
+		{[]string{"go"}, "#go_live", "go"},
+		{[]string{"nasa"}, "#NASAMoonLanding", "nasa"},
+		{[]string{"nasam"}, "#NASAMoonLanding", ""},
+		{[]string{"moon landing"}, "#MoonLandingDay", "moon landing"},
+		{[]string{"landing"}, "#MoonLandingDay", ""},
+		{[]string{"go"}, "Go 1.30 released", "go"},
+		{[]string{"go"}, "Google", ""},
+		{[]string{"GO"}, "Let's go!", "go"},
+		{[]string{"", " ", "rust", "go"}, "rust and go", "rust"},
+		{[]string{"artemis"}, "Artemis II", "artemis"},
+		{[]string{"artemis"}, "#ArtemisII", "artemis"},
+		{[]string{"art"}, "#ArtemisII", ""},
+	}
+	for _, test := range tests {
+		got := matchTwitterTrendKeyword(compileTwitterTrendKeywords(test.keywords), test.trend)
+		if got != test.want {
+			t.Errorf("matchTwitterTrendKeyword(%q, %q) = %q, want %q", test.keywords, test.trend, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Feed schedule expressions, used instead of waitMins when set:
+//
+//	"*/15 * * * *"		cron, minute hour day-of-month month day-of-week
+//	"@hourly", "@daily"	cron shorthands
+//	"every 15m"			fixed interval, any Go duration
+//	"weekdays 9-17"		waitMins interval, only Mon-Fri from 9:00 until 17:00
+//	"every 5m weekends"	combined, days can be weekdays/weekends/daily or names (mon,wed,fri)
+//
+// Times are local to the bot's host.
+type feedSchedule interface {
+	Next(from time.Time, wait time.Duration) time.Time
+}
+
+func parseFeedSchedule(expression string) (feedSchedule, error) {
+	expression = strings.TrimSpace(strings.ToLower(expression))
+	if expression == "" {
+		return nil, errors.New("empty schedule")
+	}
+	switch expression {
+	case "@hourly":
+		expression = "0 * * * *"
+	case "@daily", "@midnight":
+		expression = "0 0 * * *"
+	case "@weekly":
+		expression = "0 0 * * 0"
+	case "@monthly":
+		expression = "0 0 1 * *"
+	}
+	fields := strings.Fields(expression)
+	if len(fields) == 5 && !strings.Contains(expression, "every") && !strings.ContainsAny(fields[0], "abcdefghijklmnopqrstuvwxyz") {
+		return parseCronSchedule(fields)
+	}
+	return parseWindowSchedule(fields)
+}
+
+//#region Cron
+
+type cronSchedule struct {
+	Minutes     [60]bool
+	Hours       [24]bool
+	DaysOfMonth [32]bool
+	Months      [13]bool
+	DaysOfWeek  [7]bool
+	anyDOM      bool
+	anyDOW      bool
+}
+
+var cronDayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseCronSchedule(fields []string) (cronSchedule, error) {
+	var schedule cronSchedule
+	parts := []struct {
+		set      func(int)
+		min, max int
+	}{
+		{func(v int) { schedule.Minutes[v] = true }, 0, 59},
+		{func(v int) { schedule.Hours[v] = true }, 0, 23},
+		{func(v int) { schedule.DaysOfMonth[v] = true }, 1, 31},
+		{func(v int) { schedule.Months[v] = true }, 1, 12},
+		{func(v int) { schedule.DaysOfWeek[v%7] = true }, 0, 7},
+	}
+	for k, field := range fields {
+		if err := parseCronField(field, parts[k].min, parts[k].max, parts[k].set); err != nil {
+			return schedule, fmt.Errorf("cron field %d \"%s\": %s", k+1, field, err)
+		}
+	}
+	schedule.anyDOM = fields[2] == "*"
+	schedule.anyDOW = fields[4] == "*"
+	return schedule, nil
+}
+
+func parseCronField(field string, min int, max int, set func(int)) error {
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if index := strings.Index(item, "/"); index != -1 {
+			val, err := strconv.Atoi(item[index+1:])
+			if err != nil || val <= 0 {
+				return errors.New("invalid step")
+			}
+			step = val
+			item = item[:index]
+		}
+		start, end := min, max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0]); err != nil {
+				return err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseCronValue(bounds[1]); err != nil {
+					return err
+				}
+			} else if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return fmt.Errorf("out of range %d-%d", min, max)
+		}
+		for v := start; v <= end; v += step {
+			set(v)
+		}
+	}
+	return nil
+}
+
+func parseCronValue(value string) (int, error) {
+	for k, day := range cronDayNames {
+		if value == day {
+			return k, nil
+		}
+	}
+	return strconv.Atoi(value)
+}
+
+func (c cronSchedule) matchesDay(t time.Time) bool {
+	dom := c.DaysOfMonth[t.Day()]
+	dow := c.DaysOfWeek[int(t.Weekday())]
+	// Standard cron, if both are restricted either may match
+	if !c.anyDOM && !c.anyDOW {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+func (c cronSchedule) Next(from time.Time, _ time.Duration) time.Time {
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !c.Months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.Hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.Minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return limit
+}
+
+//#endregion
+
+//#region Window
+
+type windowSchedule struct {
+	Every     time.Duration // 0 = use waitMins
+	Days      [7]bool
+	StartHour int
+	EndHour   int // exclusive, 24 = midnight
+}
+
+func parseWindowSchedule(fields []string) (windowSchedule, error) {
+	schedule := windowSchedule{EndHour: 24}
+	daysSet := false
+	setDays := func(days ...time.Weekday) {
+		daysSet = true
+		for _, day := range days {
+			schedule.Days[day] = true
+		}
+	}
+	for k := 0; k < len(fields); k++ {
+		field := fields[k]
+		switch {
+		case field == "every":
+			if k+1 >= len(fields) {
+				return schedule, errors.New("\"every\" is missing a duration")
+			}
+			k++
+			every, err := time.ParseDuration(fields[k])
+			if err != nil {
+				return schedule, fmt.Errorf("invalid duration \"%s\"", fields[k])
+			}
+			if every < time.Minute {
+				return schedule, errors.New("interval must be at least 1m")
+			}
+			schedule.Every = every
+		case field == "weekdays":
+			setDays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+		case field == "weekends":
+			setDays(time.Saturday, time.Sunday)
+		case field == "daily":
+			setDays(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+		case strings.Contains(field, "-") && field[0] >= '0' && field[0] <= '9':
+			bounds := strings.SplitN(field, "-", 2)
+			start, err1 := strconv.Atoi(bounds[0])
+			end, err2 := strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || start < 0 || end > 24 || start >= end {
+				return schedule, fmt.Errorf("invalid hour range \"%s\"", field)
+			}
+			schedule.StartHour, schedule.EndHour = start, end
+		default:
+			found := false
+			for _, name := range strings.Split(field, ",") {
+				for day, dayName := range cronDayNames {
+					if strings.HasPrefix(name, dayName) {
+						setDays(time.Weekday(day))
+						found = true
+					}
+				}
+			}
+			if !found {
+				return schedule, fmt.Errorf("unknown schedule term \"%s\"", field)
+			}
+		}
+	}
+	if !daysSet {
+		setDays(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	}
+	return schedule, nil
+}
+
+func (w windowSchedule) inWindow(t time.Time) bool {
+	return w.Days[int(t.Weekday())] && t.Hour() >= w.StartHour && t.Hour() < w.EndHour
+}
+
+func (w windowSchedule) interval(wait time.Duration) time.Duration {
+	if w.Every > 0 {
+		wait = w.Every
+	}
+	if wait <= 0 {
+		wait = time.Minute
+	}
+	return wait
+}
+
+func (w windowSchedule) Next(from time.Time, wait time.Duration) time.Time {
+	wait = w.interval(wait)
+	next := from.Add(wait)
+	if w.inWindow(next) {
+		return next
+	}
+	// Start of the next window
+	t := time.Date(next.Year(), next.Month(), next.Day(), next.Hour(), 0, 0, 0, next.Location())
+	for k := 0; k < 24*8; k++ {
+		t = t.Add(time.Hour)
+		if w.inWindow(t) {
+			return t
+		}
+	}
+	return next
+}
+
+// First run after launch, spread over one interval so feeds on the same schedule don't all run together.
+func (w windowSchedule) NextStaggered(from time.Time, wait time.Duration) time.Time {
+	interval := w.interval(wait)
+	offset := time.Duration(rand.Int63n(int64(interval)))
+	next := w.Next(from.Add(-offset), wait)
+	if !next.Equal(from.Add(interval - offset)) { // pushed to the start of the next window, spread within it instead
+		if spread := next.Add(offset); w.inWindow(spread) {
+			next = spread
+		}
+	}
+	return next
+}
+
+//#endregion
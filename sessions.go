@@ -0,0 +1,30 @@
+package main
+
+import (
+	"time"
+)
+
+func getSessionCheckInterval() time.Duration {
+	if generalConfig.SessionCheckMins > 0 {
+		return time.Duration(generalConfig.SessionCheckMins) * time.Minute
+	}
+	return 30 * time.Minute
+}
+
+// Periodically checks the scraper logins, so sessions expiring mid-run are refreshed without a restart.
+func runSessionMonitor() {
+	if generalConfig.SessionCheckMins < 0 {
+		return
+	}
+	ticker := time.NewTicker(getSessionCheckInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctxSchedule.Done():
+			return
+		case <-ticker.C:
+		}
+		checkTwitterSessions()
+		checkInstagramSession()
+	}
+}
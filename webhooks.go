@@ -1,8 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/gtuk/discordwebhook"
@@ -35,27 +41,145 @@ func getWebhookForChannel(channel string) (*discordgo.Webhook, error) {
 // Simple function for webhook url formatting.
 func getWebhookURL(webhook *discordgo.Webhook) string {
 	if webhook != nil {
-		return fmt.Sprintf("https://discord.com/api/webhooks/%s/%s", webhook.ID, webhook.Token)
+		return discordgo.EndpointWebhookToken(webhook.ID, webhook.Token)
 	}
 	return ""
 }
 
+// File uploaded with a webhook message, embeds can show it with "attachment://<Name>".
+type webhookFile struct {
+	Name string
+	Data []byte
+}
+
+// Same as discordwebhook.SendMessage but waits for Discord to return the created message, so it can be edited later.
+func executeWebhook(webhookURL string, webhookData discordwebhook.Message, files ...webhookFile) (*discordgo.Message, error) {
+	payload := new(bytes.Buffer)
+	contentType := "application/json"
+	if len(files) == 0 {
+		if err := json.NewEncoder(payload).Encode(webhookData); err != nil {
+			return nil, err
+		}
+	} else {
+		form := multipart.NewWriter(payload)
+		payloadJson, err := json.Marshal(webhookData)
+		if err != nil {
+			return nil, err
+		}
+		if err = form.WriteField("payload_json", string(payloadJson)); err != nil {
+			return nil, err
+		}
+		for k, file := range files {
+			part, err := form.CreateFormFile(fmt.Sprintf("files[%d]", k), file.Name)
+			if err != nil {
+				return nil, err
+			}
+			if _, err = part.Write(file.Data); err != nil {
+				return nil, err
+			}
+		}
+		if err = form.Close(); err != nil {
+			return nil, err
+		}
+		contentType = form.FormDataContentType()
+	}
+
+	client, err := getProxyClient(getDiscordProxy(), 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Post(webhookURL+"?wait=true", contentType, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		return nil, errors.New(string(responseBody))
+	}
+
+	var message discordgo.Message
+	if len(responseBody) > 0 {
+		if err = json.Unmarshal(responseBody, &message); err != nil {
+			return nil, err
+		}
+	}
+	return &message, nil
+}
+
 // Send webhook, handle error returning, log in database if successful, identified by channel+ref.
 func sendWebhook(channel string, ref string, webhookData discordwebhook.Message, module string) error {
+	_, err := sendWebhookItem(channel, refItem{Ref: ref}, webhookData, module)
+	return err
+}
+
+// Same as sendWebhook but logs the extra item details used for cross-feed duplicate checks, returns the sent message.
+func sendWebhookItem(channel string, item refItem, webhookData discordwebhook.Message, module string,
+	files ...webhookFile) (*discordgo.Message, error) {
 	webhook, err := getWebhookForChannel(channel)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	webhookURL := getWebhookURL(webhook)
 	if webhookURL == "" {
-		return errors.New("error parsing webhook url")
-	} else {
-		if err = discordwebhook.SendMessage(webhookURL, webhookData); err != nil {
-			return err
-		} else {
-			refLogSent(ref, channel, module)
-		}
+		return nil, errors.New("error parsing webhook url")
+	}
+	message, err := executeWebhook(webhookURL, webhookData, files...)
+	if err != nil {
+		return nil, err
 	}
+	refLogSentItem(item, channel, module, message.ID)
 
-	return nil
+	return message, nil
+}
+
+// Appends a line listing the other sources an already sent item was suppressed for, see refFindDuplicate().
+func editWebhookAlsoReported(channel string, original dbRef, sources []string) error {
+	if original.MessageID == "" || len(sources) == 0 {
+		return nil
+	}
+	webhook, err := getWebhookForChannel(channel)
+	if err != nil {
+		return err
+	}
+	message, err := discord.WebhookMessage(webhook.ID, webhook.Token, original.MessageID)
+	if err != nil {
+		return err
+	}
+	content := message.Content
+	if index := strings.Index(content, "\n_Also reported by: "); index != -1 {
+		content = content[:index]
+	}
+	content += "\n_Also reported by: " + strings.Join(sources, ", ") + "_"
+	_, err = discord.WebhookMessageEdit(webhook.ID, webhook.Token, original.MessageID, &discordgo.WebhookEdit{
+		Content: &content,
+	})
+	return err
+}
+
+// Replaces the content and embeds of a message the bot's webhook sent to a channel.
+func editWebhookMessage(channel string, messageID string, edit *discordgo.WebhookEdit) error {
+	webhook, err := getWebhookForChannel(channel)
+	if err != nil {
+		return err
+	}
+	_, err = discord.WebhookMessageEdit(webhook.ID, webhook.Token, messageID, edit)
+	return err
+}
+
+// Checks the channel's dedup window for the same item from another feed, logging it as suppressed if found.
+func suppressDuplicate(item refItem, channel string, module string) (bool, error) {
+	original := refFindDuplicate(item, channel)
+	if original == nil {
+		return false, nil
+	}
+	refLogDuplicate(item, channel, module, *original)
+	if settings := getDiscordChannelSettings(channel); settings != nil && settings.AlsoReportedBy {
+		return true, editWebhookAlsoReported(channel, *original, refDuplicateSources(*original))
+	}
+	return true, nil
 }